@@ -0,0 +1,222 @@
+package gerr
+
+import (
+	"encoding"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tags for binding values into struct
+//
+// eg. `query:"page,required"`, `form:"since" time_format:"2006-01-02"`
+const (
+	TagQuery      = "query"
+	TagForm       = "form"
+	TagTimeFormat = "time_format"
+)
+
+const tagOptionRequired = "required"
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// BindQuery bind query string of a request into dst by `query` tags
+func BindQuery(r *http.Request, dst interface{}) error {
+	return BindValues(r.URL.Query(), dst, TagQuery)
+}
+
+// BindForm bind url-encoded or multipart form of a request into dst by `form` tags
+func BindForm(r *http.Request, dst interface{}) error {
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	var err error
+	if ct == "multipart/form-data" {
+		err = r.ParseMultipartForm(DefaultMaxBodySize)
+	} else {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		return E(http.StatusBadRequest, err.Error())
+	}
+
+	return BindValues(r.PostForm, dst, TagForm)
+}
+
+// BindValues bind values into dst, a pointer to struct, by tags
+//
+// Supported types are string, bool, numbers, time.Time, time.Duration,
+// encoding.TextUnmarshaler, pointers and slices of them.
+// Repeated params are bound into slices and their errors are indexed, eg. ["ids", "1"].
+// nil is returned when all values are bound, otherwise a CombinedError
+func BindValues(values url.Values, dst interface{}, tag string) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("gerr: bind expects a pointer to struct, got %T", dst)
+	}
+
+	items, err := bindStruct(values, v.Elem(), tag, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	return CombinedE(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), items)
+}
+
+func bindStruct(values url.Values, v reflect.Value, tag string, items []CombinedItem) ([]CombinedItem, error) {
+	t := v.Type()
+	for idx := 0; idx < t.NumField(); idx++ {
+		sf := t.Field(idx)
+		fv := v.Field(idx)
+
+		name, opts := parseBindTag(sf.Tag.Get(tag))
+		if name == "-" {
+			continue
+		}
+
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			var err error
+			items, err = bindStruct(values, fv, tag, items)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		vals := values[name]
+		if len(vals) == 0 {
+			if contains(opts, tagOptionRequired) {
+				items = append(items, CombinedItem{Keys: []string{name}, Message: name + " is required"})
+			}
+			continue
+		}
+
+		layout := sf.Tag.Get(TagTimeFormat)
+		if layout == "" {
+			layout = time.RFC3339
+		}
+
+		ft := sf.Type
+		if ft.Kind() != reflect.Slice || reflect.PtrTo(ft).Implements(textUnmarshalerType) {
+			msg, err := bindValue(vals[0], fv, layout)
+			if err != nil {
+				return nil, fmt.Errorf("gerr: field %s: %v", sf.Name, err)
+			}
+			if msg != "" {
+				items = append(items, CombinedItem{Keys: []string{name}, Message: name + " " + msg})
+			}
+			continue
+		}
+
+		slice := reflect.MakeSlice(ft, len(vals), len(vals))
+		for i := range vals {
+			msg, err := bindValue(vals[i], slice.Index(i), layout)
+			if err != nil {
+				return nil, fmt.Errorf("gerr: field %s: %v", sf.Name, err)
+			}
+			if msg != "" {
+				key := strconv.Itoa(i)
+				items = append(items, CombinedItem{Keys: []string{name, key}, Message: name + "[" + key + "] " + msg})
+			}
+		}
+		fv.Set(slice)
+	}
+	return items, nil
+}
+
+// bindValue set str into v, the message is returned when str is invalid,
+// the error is returned when the type is not supported
+func bindValue(str string, v reflect.Value, layout string) (string, error) {
+	if v.Kind() == reflect.Ptr {
+		ptr := reflect.New(v.Type().Elem())
+		msg, err := bindValue(str, ptr.Elem(), layout)
+		if msg == "" && err == nil {
+			v.Set(ptr)
+		}
+		return msg, err
+	}
+
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok && v.Type() != timeType {
+		if err := u.UnmarshalText([]byte(str)); err != nil {
+			return "is invalid", nil
+		}
+		return "", nil
+	}
+
+	switch {
+	case v.Type() == timeType:
+		tm, err := time.Parse(layout, str)
+		if err != nil {
+			return "must be a time in format " + layout, nil
+		}
+		v.Set(reflect.ValueOf(tm))
+		return "", nil
+
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return "must be a duration", nil
+		}
+		v.SetInt(int64(d))
+		return "", nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(str)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return "must be a boolean", nil
+		}
+		v.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(str, 10, v.Type().Bits())
+		if err != nil {
+			return "must be an integer", nil
+		}
+		v.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(str, 10, v.Type().Bits())
+		if err != nil {
+			return "must be a positive integer", nil
+		}
+		v.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(str, v.Type().Bits())
+		if err != nil {
+			return "must be a number", nil
+		}
+		v.SetFloat(n)
+
+	default:
+		return "", fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return "", nil
+}
+
+func parseBindTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
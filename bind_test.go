@@ -0,0 +1,94 @@
+package gerr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Paging struct {
+	Page int `query:"page,required"`
+	Size int `query:"size"`
+}
+
+type listOrderQuery struct {
+	Paging
+	IDs    []int64   `query:"ids"`
+	Paid   *bool     `query:"paid"`
+	Since  time.Time `query:"since" time_format:"2006-01-02"`
+	Status string    `query:"status"`
+	Ignore string    `query:"-"`
+}
+
+func TestBindQuery(t *testing.T) {
+	paid := true
+
+	tests := []struct {
+		name    string
+		query   string
+		want    listOrderQuery
+		wantErr error
+	}{
+		{
+			name:  "valid query",
+			query: "page=2&ids=1&ids=2&paid=true&since=2020-09-01&status=new&Ignore=x",
+			want: listOrderQuery{
+				Paging: Paging{Page: 2},
+				IDs:    []int64{1, 2},
+				Paid:   &paid,
+				Since:  time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+				Status: "new",
+			},
+		},
+		{
+			name:  "invalid query",
+			query: "size=x&ids=1&ids=a&paid=maybe&since=01-09-2020",
+			wantErr: CombinedError{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Items: []CombinedItem{
+					{Keys: []string{"page"}, Message: "page is required"},
+					{Keys: []string{"size"}, Message: "size must be an integer"},
+					{Keys: []string{"ids", "1"}, Message: "ids[1] must be an integer"},
+					{Keys: []string{"paid"}, Message: "paid must be a boolean"},
+					{Keys: []string{"since"}, Message: "since must be a time in format 2006-01-02"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/orders?"+tt.query, nil)
+
+			var got listOrderQuery
+			err := BindQuery(r, &got)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("BindQuery() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BindQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindForm(t *testing.T) {
+	form := url.Values{"name": {"gerr"}, "tags": {"a", "b"}}
+	r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var got struct {
+		Name string   `form:"name,required"`
+		Tags []string `form:"tags"`
+	}
+	if err := BindForm(r, &got); err != nil {
+		t.Fatalf("BindForm() error = %v", err)
+	}
+	if got.Name != "gerr" || !reflect.DeepEqual(got.Tags, []string{"a", "b"}) {
+		t.Errorf("BindForm() = %v", got)
+	}
+}
@@ -0,0 +1,164 @@
+package cleanpath
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// modCacheDir directory of the module cache in a path, eg. /home/runner/go/pkg/mod/
+const modCacheDir = "/pkg/mod/"
+
+var (
+	repoRoot string
+
+	rootsOnce sync.Once
+	modRoots  []string
+	goRoots   []string
+	srcRoots  []string
+)
+
+// SetRepoRoot set root directory of the repository, paths in it are made relative to it
+//
+// eg. SetRepoRoot("/home/runner/work/app") makes "/home/runner/work/app/api/handler.go" "api/handler.go"
+func SetRepoRoot(dir string) {
+	if dir == "" {
+		repoRoot = ""
+		return
+	}
+	repoRoot = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(dir)), "/") + "/"
+}
+
+// GetRepoRoot get root directory of the repository
+func GetRepoRoot() string {
+	return strings.TrimSuffix(repoRoot, "/")
+}
+
+/*
+Clean makes a path of a source file short and independent of the build machine.
+
+  - Files in the repo root are relative to it, see SetRepoRoot
+  - Files in the module cache are printed as module@version/file.go,
+    eg. "github.com/sirupsen/logrus@v1.8.1/entry.go"
+  - Files in GOROOT/src are relative to it, eg. "net/http/server.go"
+  - Files in GOPATH/src are relative to it, the same as RemoveGoPath
+*/
+func Clean(path string) string {
+	if path == "" {
+		return path
+	}
+	p := filepath.ToSlash(path)
+
+	if repoRoot != "" && strings.HasPrefix(p, repoRoot) {
+		return p[len(repoRoot):]
+	}
+
+	rootsOnce.Do(loadRoots)
+	if rel, ok := trimModCache(p); ok {
+		return rel
+	}
+	for _, root := range goRoots {
+		if strings.HasPrefix(p, root) {
+			return p[len(root):]
+		}
+	}
+	for _, root := range srcRoots {
+		if strings.HasPrefix(p, root) {
+			return p[len(root):]
+		}
+	}
+	return path
+}
+
+func trimModCache(p string) (string, bool) {
+	rel := ""
+	for _, root := range modRoots {
+		if strings.HasPrefix(p, root) {
+			rel = p[len(root):]
+			break
+		}
+	}
+
+	// the module cache of the build machine, eg. /home/runner/go/pkg/mod/
+	if rel == "" {
+		idx := strings.LastIndex(p, modCacheDir)
+		if idx < 0 {
+			return "", false
+		}
+		rel = p[idx+len(modCacheDir):]
+	}
+
+	// module@version, not the download cache, eg. cache/download/module/@v/list
+	at := strings.Index(rel, "@")
+	if at <= 0 || rel[at-1] == '/' {
+		return "", false
+	}
+	return unescapeModulePath(rel[:at]) + rel[at:], true
+}
+
+// unescapeModulePath revert escaped upper case letters of the module cache, eg. "!burnt!sushi" -> "BurntSushi"
+func unescapeModulePath(path string) string {
+	if !strings.Contains(path, "!") {
+		return path
+	}
+
+	b := new(strings.Builder)
+	upper := false
+	for _, ch := range path {
+		switch {
+		case ch == '!':
+			upper = true
+			continue
+		case upper:
+			b.WriteRune(unicode.ToUpper(ch))
+		default:
+			b.WriteRune(ch)
+		}
+		upper = false
+	}
+	return b.String()
+}
+
+func loadRoots() {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		modRoots = append(modRoots, dirPrefix(dir))
+	}
+	for _, dir := range filepath.SplitList(goPath()) {
+		if dir != "" {
+			modRoots = append(modRoots, dirPrefix(filepath.Join(dir, "pkg", "mod")))
+		}
+	}
+
+	for _, dir := range []string{os.Getenv("GOROOT"), runtime.GOROOT()} {
+		if dir != "" {
+			goRoots = append(goRoots, dirPrefix(filepath.Join(dir, "src")))
+		}
+	}
+
+	// the same roots as RemoveGoPath, computed once instead of for every frame
+	for _, dir := range filepath.SplitList(os.Getenv("GOPATH")) {
+		if dir != "" {
+			srcRoots = append(srcRoots, dirPrefix(filepath.Join(dir, "src")))
+		}
+	}
+	sort.Stable(longestFirst(srcRoots))
+}
+
+// goPath GOPATH environment variable or its default $HOME/go
+func goPath() string {
+	if dir := os.Getenv("GOPATH"); dir != "" {
+		return dir
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, "go")
+	}
+	return ""
+}
+
+func dirPrefix(dir string) string {
+	return strings.TrimSuffix(filepath.ToSlash(filepath.Clean(dir)), "/") + "/"
+}
@@ -0,0 +1,38 @@
+package cleanpath
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestClean(t *testing.T) {
+	defer SetRepoRoot(GetRepoRoot())
+	SetRepoRoot("/home/runner/work/app/")
+
+	tests := map[string]string{
+		"":                                     "",
+		"/home/runner/work/app/api/handler.go": "api/handler.go",
+		"/home/runner/go/pkg/mod/github.com/sirupsen/logrus@v1.8.1/entry.go": "github.com/sirupsen/logrus@v1.8.1/entry.go",
+		"/root/go/pkg/mod/github.com/!burnt!sushi/toml@v1.2.0/decode.go":     "github.com/BurntSushi/toml@v1.2.0/decode.go",
+		"/root/go/pkg/mod/cache/download/github.com/sirupsen/logrus/@v/list": "/root/go/pkg/mod/cache/download/github.com/sirupsen/logrus/@v/list",
+		runtime.GOROOT() + "/src/net/http/server.go":                         "net/http/server.go",
+		"/opt/build/main.go": "/opt/build/main.go",
+	}
+	for path, want := range tests {
+		if got := Clean(path); got != want {
+			t.Errorf("Clean(%v) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func Test_unescapeModulePath(t *testing.T) {
+	tests := map[string]string{
+		"github.com/sirupsen/logrus":  "github.com/sirupsen/logrus",
+		"github.com/!azure/go-!s!d!k": "github.com/Azure/go-SDK",
+	}
+	for path, want := range tests {
+		if got := unescapeModulePath(path); got != want {
+			t.Errorf("unescapeModulePath(%v) = %v, want %v", path, got, want)
+		}
+	}
+}
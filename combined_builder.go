@@ -0,0 +1,197 @@
+package gerr
+
+import (
+	"errors"
+	"net/http"
+)
+
+// CombinedBuilder builder for combined key error
+//
+//	v := gerr.NewCombined(400, "bad request")
+//	v.AddIf(req.Name == "", "name", "name is required")
+//	items := v.Nest("items")
+//	items.Add("[0].productId", "product is required")
+//	return v.Err()
+type CombinedBuilder struct {
+	root   *combinedState
+	prefix []string
+}
+
+type combinedState struct {
+	CombinedError
+	mapper *PathMapper
+}
+
+// NewCombined make a combined key error builder
+func NewCombined(code int, msg string) *CombinedBuilder {
+	return &CombinedBuilder{
+		root: &combinedState{
+			CombinedError: CombinedError{Code: code, Message: msg},
+		},
+	}
+}
+
+// Mapper map Go field names in paths of the whole error to wire names when making the error
+func (b *CombinedBuilder) Mapper(m PathMapper) *CombinedBuilder {
+	b.root.mapper = &m
+	return b
+}
+
+// Add add an item, path is parsed by ParsePath and prefixed by the nested prefix
+func (b *CombinedBuilder) Add(path string, msg string) *CombinedBuilder {
+	b.root.Items = append(b.root.Items, CombinedItem{
+		Keys:    b.keys(path),
+		Message: msg,
+	})
+	return b
+}
+
+// AddIf add an item when cond is true
+func (b *CombinedBuilder) AddIf(cond bool, path string, msg string) *CombinedBuilder {
+	if cond {
+		b.Add(path, msg)
+	}
+	return b
+}
+
+// AddRule add a rule error on several fields, paths are parsed by ParsePath
+//
+// eg. v.AddRule("dateRange", "startDate must be before endDate", "startDate", "endDate")
+func (b *CombinedBuilder) AddRule(rule string, msg string, paths ...string) *CombinedBuilder {
+	keys := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		keys = append(keys, b.keys(path))
+	}
+
+	b.root.Rules = append(b.root.Rules, CombinedRule{
+		Rule:    rule,
+		Keys:    keys,
+		Message: msg,
+	})
+	return b
+}
+
+// Merge add items of err under the nested prefix
+//
+// Items of a CombinedError are kept. An Error becomes an item with its Message and
+// the items of its Flatten, an Error hidden by ResponsePolicy (5xx or no code) becomes an item with
+// the public message, so do its hidden children. Other errors are handled as internal errors, their text is never
+// put in items unless debug mode is on
+func (b *CombinedBuilder) Merge(err error) *CombinedBuilder {
+	if err == nil {
+		return b
+	}
+
+	var combinedErr CombinedError
+	if errors.As(err, &combinedErr) {
+		return b.mergeCombinedError(combinedErr)
+	}
+
+	e, ok := asError(err)
+	if !ok {
+		e = Error{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	return b.mergeError(e)
+}
+
+func (b *CombinedBuilder) mergeCombinedError(combinedErr CombinedError) *CombinedBuilder {
+	for _, itm := range makeItemsFromCombinedError(combinedErr) {
+		b.root.Items = append(b.root.Items, CombinedItem{
+			Keys:    b.join(itm.Keys),
+			Message: itm.Message,
+		})
+	}
+
+	for _, rule := range makeRulesFromCombinedError(combinedErr) {
+		keys := make([][]string, 0, len(rule.Keys))
+		for _, k := range rule.Keys {
+			keys = append(keys, b.join(k))
+		}
+		rule.Keys = keys
+		b.root.Rules = append(b.root.Rules, rule)
+	}
+	return b
+}
+
+func (b *CombinedBuilder) mergeError(e Error) *CombinedBuilder {
+	if responsePolicy.hides(e) {
+		return b.Add("", responsePolicy.publicMessage(e))
+	}
+
+	var keys []string
+	if e.Target != "" {
+		keys = []string{e.Target}
+	}
+
+	if e.Message != "" {
+		b.root.Items = append(b.root.Items, CombinedItem{
+			Keys:    b.join(keys),
+			Message: e.Message,
+		})
+	}
+
+	for _, itm := range responsePolicy.redact(e).Flatten() {
+		b.root.Items = append(b.root.Items, CombinedItem{
+			Keys:    b.join(append(keys[:len(keys):len(keys)], itm.Keys...)),
+			Message: itm.Message,
+		})
+	}
+	return b
+}
+
+// Nest make a builder for a sub-object, items are added into the same error
+func (b *CombinedBuilder) Nest(prefix string) *CombinedBuilder {
+	return &CombinedBuilder{
+		root:   b.root,
+		prefix: b.keys(prefix),
+	}
+}
+
+// Len number of items and rules in the whole error
+func (b *CombinedBuilder) Len() int {
+	return len(b.root.Items) + len(b.root.Rules)
+}
+
+// Err make combined key error, nil is returned when there is no item
+func (b *CombinedBuilder) Err() error {
+	if b.Len() == 0 {
+		return nil
+	}
+
+	rs := b.root.CombinedError
+	rs.Items = append([]CombinedItem(nil), b.root.Items...)
+	rs.Rules = append([]CombinedRule(nil), b.root.Rules...)
+
+	if b.root.mapper != nil {
+		rs = b.root.mapper.MapCombinedError(rs)
+	}
+	return rs
+}
+
+func (b *CombinedBuilder) keys(path string) []string {
+	keys, err := ParsePath(path)
+	if err != nil {
+		keys = []string{path}
+	}
+	return b.join(keys)
+}
+
+func (b *CombinedBuilder) join(keys []string) []string {
+	rs := make([]string, 0, len(b.prefix)+len(keys))
+	rs = append(rs, b.prefix...)
+	return append(rs, keys...)
+}
+
+// asError get Error or *Error in the chain of err
+func asError(err error) (Error, bool) {
+	var e Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+
+	var ptr *Error
+	if errors.As(err, &ptr) && ptr != nil {
+		return *ptr, true
+	}
+	return Error{}, false
+}
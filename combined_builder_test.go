@@ -0,0 +1,144 @@
+package gerr
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCombinedBuilder(t *testing.T) {
+	validateItem := func(productID string) error {
+		return NewCombined(400, "bad request").
+			AddIf(productID == "", "productId", "product is required").
+			Err()
+	}
+
+	tests := []struct {
+		name  string
+		build func(v *CombinedBuilder)
+		want  error
+	}{
+		{
+			name:  "no item",
+			build: func(v *CombinedBuilder) {},
+			want:  nil,
+		},
+		{
+			name: "items with nested and merged errors",
+			build: func(v *CombinedBuilder) {
+				v.Add("user.name", "name is required")
+				v.AddIf(false, "user.email", "email is required")
+
+				items := v.Nest("items")
+				items.Nest("[1]").Merge(validateItem(""))
+				items.Nest("[2]").Merge(validateItem("p2"))
+				items.Merge(E(http.StatusBadRequest, "too many items"))
+			},
+			want: CombinedError{
+				Code:    400,
+				Message: "bad request",
+				Items: []CombinedItem{
+					{Keys: []string{"user", "name"}, Message: "name is required"},
+					{Keys: []string{"items", "1", "productId"}, Message: "product is required"},
+					{Keys: []string{"items"}, Message: "too many items"},
+				},
+			},
+		},
+		{
+			name: "merged errors",
+			build: func(v *CombinedBuilder) {
+				v.Nest("user").Merge(E(ErrIDInvalid, Target("id"), []Error{{Target: "raw", Message: "must be a number"}}))
+				v.Nest("user").Merge(&Error{Code: http.StatusBadRequest, Message: "user is invalid"})
+			},
+			want: CombinedError{
+				Code:    400,
+				Message: "bad request",
+				Items: []CombinedItem{
+					{Keys: []string{"user", "id"}, Message: "id invalid"},
+					{Keys: []string{"user", "id", "raw"}, Message: "must be a number"},
+					{Keys: []string{"user"}, Message: "user is invalid"},
+				},
+			},
+		},
+		{
+			name: "merged internal errors are hidden",
+			build: func(v *CombinedBuilder) {
+				v.Nest("user").Merge(E(ErrSvcTimeout, "db down"))
+				v.Merge(errors.New("dial tcp 10.0.0.1:5432: connection refused"))
+				v.Nest("order").Merge(E(http.StatusBadRequest, []Error{{Code: ErrSvcLostConnection, Target: "db", Message: "connection refused"}}))
+			},
+			want: CombinedError{
+				Code:    400,
+				Message: "bad request",
+				Items: []CombinedItem{
+					{Keys: []string{"user"}, Message: "Internal Server Error"},
+					{Keys: []string{}, Message: "Internal Server Error"},
+					{Keys: []string{"order"}, Message: "Bad Request"},
+					{Keys: []string{"order", "db"}, Message: "Internal Server Error"},
+				},
+			},
+		},
+	}
+	defer SetResponsePolicy(GetResponsePolicy())
+	SetResponsePolicy(ResponsePolicy{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewCombined(400, "bad request")
+			tt.build(v)
+
+			got := v.Err()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Err() = %#v, want %#v", got, tt.want)
+			}
+			if tt.want == nil && got != nil {
+				t.Errorf("Err() = %#v, want untyped nil", got)
+			}
+		})
+	}
+}
+
+func TestCombinedBuilder_AddRule(t *testing.T) {
+	v := NewCombined(400, "bad request")
+	v.Add("endDate", "endDate is invalid")
+	v.Nest("period").AddRule("dateRange", "startDate must be before endDate", "startDate", "endDate")
+
+	var combinedErr CombinedError
+	if !errors.As(v.Err(), &combinedErr) {
+		t.Fatalf("Err() = %v, want CombinedError", v.Err())
+	}
+
+	want := ErrResponse{
+		Message: "bad request",
+		Errors: ErrDetailResponse{
+			"endDate": []interface{}{"endDate is invalid"},
+			"period": map[string]interface{}{
+				"startDate": []interface{}{"startDate must be before endDate"},
+				"endDate":   []interface{}{"startDate must be before endDate"},
+			},
+			RulesTarget: map[string]interface{}{
+				"dateRange": []interface{}{"startDate must be before endDate"},
+			},
+		},
+	}
+	if got := combinedErr.ToResponseError(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToResponseError() = %v, want %v", got, want)
+	}
+}
+
+func TestCombinedE_ruleWithEmptyKey(t *testing.T) {
+	err := CombinedE(400, "bad", CombinedRule{Rule: "r", Keys: [][]string{{"a", "", "b"}}, Message: "m"})
+
+	want := ErrResponse{
+		Message: "bad",
+		Errors: ErrDetailResponse{
+			RulesTarget: map[string]interface{}{
+				"r": []interface{}{"m"},
+			},
+		},
+	}
+	if got := err.ToResponseError(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToResponseError() = %v, want %v", got, want)
+	}
+}
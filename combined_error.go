@@ -12,6 +12,7 @@ type CombinedError struct {
 	Message string
 	Target  string
 	Items   []CombinedItem
+	Rules   []CombinedRule
 }
 
 // CombinedItem detail for combined key error model
@@ -23,22 +24,72 @@ type CombinedItem struct {
 	Message string
 }
 
+// CombinedRule error of a rule on several fields
+// Rule is rule code, eg. "dateRange"
+// Keys are keys of the fields
+//     - [["startDate"], ["endDate"]]
+// Message is error message
+//
+// The message is under each field and once under RulesTarget in the errors tree
+type CombinedRule struct {
+	Rule    string
+	Keys    [][]string
+	Message string
+}
+
+const (
+	// RulesTarget target of rule errors in the errors tree
+	RulesTarget = "_rules"
+
+	// MetaKeyRule meta key for rule code of field errors
+	MetaKeyRule = "rule"
+)
+
 // ToError make error from combined key error
+//
+// The stack is captured by StackPolicy
 func (e CombinedError) ToError() *Error {
-	return makeErrorFromCombinedError(e)
+	rs := makeErrorFromCombinedError(e)
+	if stackPolicy.captures(e.Code) {
+		rs.trace = newStackTrace(captureStack(1, stackPolicy.depth()))
+	}
+	return rs
+}
+
+// Error make error message from combined key error
+func (e CombinedError) Error() string {
+	return makeErrorFromCombinedError(e).Error()
+}
+
+// ToResponseError make response err from combined key error
+func (e CombinedError) ToResponseError() ErrResponse {
+	return NewResponseError(*makeErrorFromCombinedError(e))
 }
 
 // CombinedE helper func for init combined key error
+//
+// A Path puts the message and items under its keys, eg. CombinedE(400, Path("items[0].productId"), "product is required")
 func CombinedE(args ...interface{}) CombinedError {
 	if len(args) == 0 {
 		panic("call to errors.E with no arguments")
 	}
 
 	e := CombinedError{}
+	var mapper *PathMapper
+	isPath := false
 	for _, arg := range args {
 		switch arg := arg.(type) {
 		case Target:
 			e.Target = string(arg)
+			isPath = false
+
+		case PathMapper:
+			copy := arg
+			mapper = &copy
+
+		case Path:
+			e.Target = string(arg)
+			isPath = true
 
 		case Message:
 			e.Message = string(arg)
@@ -52,6 +103,14 @@ func CombinedE(args ...interface{}) CombinedError {
 		case Code:
 			e.Code = int(arg)
 
+		case CombinedError:
+			e.Items = append(e.Items, makeItemsFromCombinedError(arg)...)
+			e.Rules = append(e.Rules, makeRulesFromCombinedError(arg)...)
+
+		case *CombinedError:
+			e.Items = append(e.Items, makeItemsFromCombinedError(*arg)...)
+			e.Rules = append(e.Rules, makeRulesFromCombinedError(*arg)...)
+
 		case *CombinedItem:
 			// Make a copy
 			copy := *arg
@@ -75,6 +134,15 @@ func CombinedE(args ...interface{}) CombinedError {
 				e.Items = append(e.Items, *currErr)
 			}
 
+		case CombinedRule:
+			e.Rules = append(e.Rules, arg)
+
+		case *CombinedRule:
+			e.Rules = append(e.Rules, *arg)
+
+		case []CombinedRule:
+			e.Rules = append(e.Rules, arg...)
+
 		default:
 			_, file, line, _ := runtime.Caller(1)
 			log.Printf("errors.E: bad call from %s:%d: %v", file, line, args)
@@ -83,9 +151,105 @@ func CombinedE(args ...interface{}) CombinedError {
 			e.Items = append(e.Items, errChild)
 		}
 	}
+
+	// the message and items are moved under the path, so the path is kept in responses
+	if isPath {
+		e.Items = makeItemsFromCombinedError(e)
+		e.Rules = makeRulesFromCombinedError(e)
+		e.Target = ""
+	}
+
+	if mapper != nil {
+		e = mapper.MapCombinedError(e)
+	}
 	return e
 }
 
+// Flatten make combined key items from the errors tree
+//
+// Keys of an item are the targets from the root to the error with a message
+func (e Error) Flatten() []CombinedItem {
+	return doFlatten(e.Errors, nil, newErrorPath(e), nil)
+}
+
+// ToCombinedError make combined key error from error, the inverse of CombinedError.ToError
+func (e Error) ToCombinedError() CombinedError {
+	return CombinedError{
+		Code:    e.Code,
+		Message: e.Message,
+		Target:  e.Target,
+		Items:   e.Flatten(),
+	}
+}
+
+func doFlatten(errs []*Error, keys []string, path errorPath, rs []CombinedItem) []CombinedItem {
+	for idx := range errs {
+		itm := errs[idx]
+		if itm == nil || path.has(itm) {
+			continue
+		}
+
+		currKeys := keys
+		if itm.Target != "" {
+			currKeys = append(keys[:len(keys):len(keys)], itm.Target)
+		}
+
+		if itm.Message != "" {
+			rs = append(rs, CombinedItem{Keys: currKeys, Message: itm.Message})
+		}
+
+		path.errs[itm] = true
+		rs = doFlatten(itm.Errors, currKeys, path, rs)
+		delete(path.errs, itm)
+	}
+	return rs
+}
+
+// makeItemsFromCombinedError make items from a nested combined key error
+//
+// Target of the nested error is parsed as a Path and prefixes keys of its items
+func makeItemsFromCombinedError(err CombinedError) []CombinedItem {
+	keys := getCombinedTargetKeys(err)
+
+	rs := make([]CombinedItem, 0, len(err.Items)+1)
+	if err.Message != "" && len(keys) > 0 {
+		rs = append(rs, CombinedItem{Keys: keys, Message: err.Message})
+	}
+
+	for idx := range err.Items {
+		itm := err.Items[idx]
+		rs = append(rs, CombinedItem{
+			Keys:    append(keys[:len(keys):len(keys)], itm.Keys...),
+			Message: itm.Message,
+		})
+	}
+	return rs
+}
+
+// makeRulesFromCombinedError make rules from a nested combined key error
+func makeRulesFromCombinedError(err CombinedError) []CombinedRule {
+	keys := getCombinedTargetKeys(err)
+
+	rs := make([]CombinedRule, 0, len(err.Rules))
+	for idx := range err.Rules {
+		rule := err.Rules[idx]
+		ruleKeys := make([][]string, 0, len(rule.Keys))
+		for _, k := range rule.Keys {
+			ruleKeys = append(ruleKeys, append(keys[:len(keys):len(keys)], k...))
+		}
+		rs = append(rs, CombinedRule{Rule: rule.Rule, Keys: ruleKeys, Message: rule.Message})
+	}
+	return rs
+}
+
+func getCombinedTargetKeys(err CombinedError) []string {
+	keys := Path(err.Target).Keys()
+	if keys == nil && err.Target != "" {
+		keys = []string{err.Target}
+	}
+	return keys
+}
+
 // makeErrorFromCombinedError make error form combined key error
 func makeErrorFromCombinedError(err CombinedError) *Error {
 	rs := &Error{
@@ -96,12 +260,30 @@ func makeErrorFromCombinedError(err CombinedError) *Error {
 
 	for idx := range err.Items {
 		itm := err.Items[idx]
+		if len(itm.Keys) == 0 {
+			rs.Errors = append(rs.Errors, &Error{Message: itm.Message})
+			continue
+		}
 		doMakeChildren(itm.Keys, itm.Message, rs)
 	}
+
+	for idx := range err.Rules {
+		rule := err.Rules[idx]
+		for _, keys := range rule.Keys {
+			// keys with an empty key have no field to mention
+			if len(keys) == 0 || hasEmptyKey(keys) {
+				continue
+			}
+			node := doMakeChildren(keys, rule.Message, rs)
+			node.Meta = map[string]interface{}{MetaKeyRule: rule.Rule}
+		}
+		doMakeChildren([]string{RulesTarget, rule.Rule}, rule.Message, rs)
+	}
 	return rs
 }
 
-func doMakeChildren(keys []string, msg string, err *Error) {
+// doMakeChildren make a leaf error with keys as target chain, the leaf is returned
+func doMakeChildren(keys []string, msg string, err *Error) *Error {
 	if len(keys) == 1 {
 		newNode := &Error{Target: keys[0], Message: msg}
 		if len(err.Errors) > 0 {
@@ -109,13 +291,13 @@ func doMakeChildren(keys []string, msg string, err *Error) {
 		} else {
 			err.Errors = []*Error{newNode}
 		}
-		return
+		return newNode
 	}
 
 	currNode := err
 	currKey, keys := popKey(keys)
 	if currKey == "" {
-		return
+		return nil
 	}
 
 	found := false
@@ -138,7 +320,16 @@ func doMakeChildren(keys []string, msg string, err *Error) {
 		}
 		currNode = newNode
 	}
-	doMakeChildren(keys, msg, currNode)
+	return doMakeChildren(keys, msg, currNode)
+}
+
+func hasEmptyKey(keys []string) bool {
+	for idx := range keys {
+		if keys[idx] == "" {
+			return true
+		}
+	}
+	return false
 }
 
 func popKey(arr []string) (string, []string) {
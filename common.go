@@ -28,6 +28,9 @@ func getStatusCode(code int) int {
 	}
 
 	if code < internalCodeMax {
+		if status, ok := internalStatus[code]; ok {
+			return status
+		}
 		return http.StatusInternalServerError
 	}
 
@@ -1,5 +1,7 @@
 package gerr
 
+import "net/http"
+
 const (
 	internalCodeMin = iota + 1000
 
@@ -31,6 +33,13 @@ const (
 	internalCodeMax         = int(10000)
 )
 
+// Internal codes with a fixed value, they don't shift InternalCodeCustomStart.
+// Custom codes should stay below them.
+const (
+	// ErrPanicRecovered a panic is recovered by Recover
+	ErrPanicRecovered = internalCodeMax - 1
+)
+
 var internalMsg = map[int]string{
 	ErrIOInvalidPath:       "invalid path",
 	ErrIONotExist:          "not exist",
@@ -38,6 +47,12 @@ var internalMsg = map[int]string{
 	ErrIOReadFailed:        "read failed",
 	ErrIOContentReachLimit: "content reach limit",
 	ErrIOWriteFailed:       "write failed",
+	ErrPanicRecovered:      "panic recovered",
+}
+
+// internalStatus http status of internal codes which are caused by the client, others are 500
+var internalStatus = map[int]int{
+	ErrIOContentReachLimit: http.StatusRequestEntityTooLarge,
 }
 
 func getInternalMessage(code int) string {
@@ -1,7 +1,7 @@
 package gerr
 
 const (
-	serviceCodeMin = iota + serviceCodeMax
+	serviceCodeMin = iota + internalCodeMax
 
 	// ErrSvcTimeout sevice Timeout
 	ErrSvcTimeout
@@ -2,3 +2,6 @@ package gerr
 
 // Separator separator for format
 const Separator = ":\n\t"
+
+// cycleMessage message for an error which is one of its ancestors
+const cycleMessage = "<cycle>"
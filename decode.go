@@ -0,0 +1,120 @@
+package gerr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	// DefaultMaxBodySize default limit of request body, 1 MiB
+	DefaultMaxBodySize = int64(1 << 20)
+
+	// MetaKeyLimit meta key for size limit
+	MetaKeyLimit = "limit"
+)
+
+// Messages of request body errors
+const (
+	MessageEmptyBody    = "request body is empty"
+	MessageTrailingData = "request body must contain a single JSON value"
+
+	// MessageBodyTooLarge format of the message with the limit in bytes
+	MessageBodyTooLarge = "request body must be at most %d bytes"
+)
+
+type decodeOptions struct {
+	maxBodySize        int64
+	allowUnknownFields bool
+	allowEmptyBody     bool
+	validate           func(interface{}) error
+}
+
+// DecodeOption option for DecodeJSON
+type DecodeOption func(*decodeOptions)
+
+// MaxBodySize set limit of request body, DefaultMaxBodySize is used by default
+func MaxBodySize(n int64) DecodeOption {
+	return func(o *decodeOptions) {
+		o.maxBodySize = n
+	}
+}
+
+// AllowUnknownFields accept fields which are not in the destination
+func AllowUnknownFields() DecodeOption {
+	return func(o *decodeOptions) {
+		o.allowUnknownFields = true
+	}
+}
+
+// AllowEmptyBody accept empty body, the destination is left untouched
+func AllowEmptyBody() DecodeOption {
+	return func(o *decodeOptions) {
+		o.allowEmptyBody = true
+	}
+}
+
+// WithValidator validate the destination after decoding, eg. validate.Struct
+func WithValidator(fn func(interface{}) error) DecodeOption {
+	return func(o *decodeOptions) {
+		o.validate = fn
+	}
+}
+
+// DecodeJSON decode json body of a request into dst
+//
+// Errors are
+//   - ErrIOContentReachLimit (413) with the limit in message and meta when body is larger than the limit
+//   - 400 for empty body, unknown fields, trailing data and invalid json, see FromJSONError
+//   - errors of the validator
+func DecodeJSON(r *http.Request, dst interface{}, opts ...DecodeOption) error {
+	o := decodeOptions{maxBodySize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(io.LimitReader(r.Body, o.maxBodySize+1))
+		if err != nil {
+			return E(ErrIOReadFailed, err.Error())
+		}
+	}
+
+	if int64(len(body)) > o.maxBodySize {
+		return E(
+			ErrIOContentReachLimit,
+			fmt.Sprintf(MessageBodyTooLarge, o.maxBodySize),
+			Meta{MetaKeyLimit: o.maxBodySize},
+		)
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if o.allowEmptyBody {
+			return nil
+		}
+		return E(http.StatusBadRequest, MessageEmptyBody)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if !o.allowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+
+	if err := dec.Decode(dst); err != nil {
+		return FromJSONErrorWithInput(err, body)
+	}
+
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		return E(http.StatusBadRequest, MessageTrailingData)
+	}
+
+	if o.validate != nil {
+		return o.validate(dst)
+	}
+	return nil
+}
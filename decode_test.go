@@ -0,0 +1,126 @@
+package gerr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	type order struct {
+		ID       string `json:"id"`
+		Quantity int    `json:"quantity"`
+	}
+
+	errInvalid := errors.New("invalid")
+
+	tests := []struct {
+		name        string
+		body        string
+		opts        []DecodeOption
+		wantCode    int
+		wantMessage string
+		wantErr     error
+	}{
+		{
+			name: "valid body",
+			body: `{"id":"o-1","quantity":1}`,
+		},
+		{
+			name:     "body reach limit",
+			body:     `{"id":"o-1","quantity":1}`,
+			opts:     []DecodeOption{MaxBodySize(10)},
+			wantCode: ErrIOContentReachLimit,
+		},
+		{
+			name:        "empty body",
+			body:        "  ",
+			wantCode:    http.StatusBadRequest,
+			wantMessage: MessageEmptyBody,
+		},
+		{
+			name: "allow empty body",
+			body: "",
+			opts: []DecodeOption{AllowEmptyBody()},
+		},
+		{
+			name:        "unknown field",
+			body:        `{"id":"o-1","note":"x"}`,
+			wantCode:    http.StatusBadRequest,
+			wantMessage: MessageInvalidJSON,
+		},
+		{
+			name: "allow unknown field",
+			body: `{"id":"o-1","note":"x"}`,
+			opts: []DecodeOption{AllowUnknownFields()},
+		},
+		{
+			name:        "trailing data",
+			body:        `{"id":"o-1"} {}`,
+			wantCode:    http.StatusBadRequest,
+			wantMessage: MessageTrailingData,
+		},
+		{
+			name:    "validator error",
+			body:    `{"id":"o-1"}`,
+			opts:    []DecodeOption{WithValidator(func(interface{}) error { return errInvalid })},
+			wantErr: errInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tt.body))
+
+			var dst order
+			err := DecodeJSON(r, &dst, tt.opts...)
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("DecodeJSON() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("DecodeJSON() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var e Error
+			if !errors.As(err, &e) {
+				t.Fatalf("DecodeJSON() error = %v, want Error", err)
+			}
+			if e.Code != tt.wantCode || (tt.wantMessage != "" && e.Message != tt.wantMessage) {
+				t.Errorf("DecodeJSON() error = %v, want code %v message %v", e, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestDecodeJSON_limit(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id":"o-1"}`))
+
+	var dst struct{}
+	err := DecodeJSON(r, &dst, MaxBodySize(4))
+
+	e, ok := err.(Error)
+	if !ok {
+		t.Fatalf("DecodeJSON() error = %v, want Error", err)
+	}
+	if e.Code != ErrIOContentReachLimit || e.StatusCode() != http.StatusRequestEntityTooLarge || e.Meta[MetaKeyLimit] != int64(4) {
+		t.Errorf("DecodeJSON() error = %v, want ErrIOContentReachLimit with status 413 and limit 4", e)
+	}
+
+	defer SetResponsePolicy(GetResponsePolicy())
+	SetResponsePolicy(ResponsePolicy{})
+
+	data, _ := json.Marshal(e.ToResponseError())
+	if want := `{"message":"request body must be at most 4 bytes"}`; string(data) != want {
+		t.Errorf("ToResponseError() = %s, want %s", data, want)
+	}
+}
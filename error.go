@@ -2,6 +2,8 @@ package gerr
 
 import (
 	"bytes"
+	"fmt"
+	"sort"
 	"strconv"
 )
 
@@ -12,6 +14,7 @@ import (
 // Message: error message
 // Target: object is mentioned in message
 // Errors: error details
+// Meta: additional information about the error
 type Error struct {
 	TraceID string
 	Code    int
@@ -19,59 +22,87 @@ type Error struct {
 	Target  string
 	Op      string
 	Errors  []*Error
+	Meta    map[string]interface{}
 	trace   *stacktrace
+	hops    []hop
 }
 
+// Error make error message, the stack trace is printed once and cached
 func (e Error) Error() string {
-	b := new(bytes.Buffer)
+	var path [8]*Error
+	b := getBuffer()
+	e.format(b, e.Errors, path[:0])
+	rs := b.String()
+	putBuffer(b)
+	return rs
+}
+
+// format write error message to b, root is the errors of the root and
+// path holds the errors from the root to detect cycles
+func (e Error) format(b *bytes.Buffer, root []*Error, path []*Error) {
+	start := b.Len()
 
 	if e.TraceID != "" {
-		pad(b, "traceId: ")
+		pad(b, start, "traceId: ")
 		b.WriteString(e.TraceID)
 	}
 
 	if e.Op != "" {
-		pad(b, "op: ")
+		pad(b, start, "op: ")
 		b.WriteString(e.Op)
 	}
 
 	if e.Code > 0 {
-		pad(b, "code: ")
-		b.WriteString(strconv.Itoa(e.Code))
+		pad(b, start, "code: ")
+		var num [20]byte
+		b.Write(strconv.AppendInt(num[:0], int64(e.Code), 10))
 	}
 
 	if e.Target != "" {
-		pad(b, "target: ")
+		pad(b, start, "target: ")
 		b.WriteString(e.Target)
 	}
 
 	if e.Message != "" {
-		pad(b, "message: ")
+		pad(b, start, "message: ")
 		b.WriteString(e.Message)
 	}
 
+	if len(e.Meta) > 0 {
+		pad(b, start, "meta: ")
+		writeMeta(b, e.Meta)
+	}
+
 	if e.trace != nil {
-		pad(b, "trace: \n")
-		b.WriteString(e.trace.file + ":" + strconv.Itoa(e.trace.line) + " (" + e.trace.function + ")")
-		if e.trace.fullTrace != "" {
-			b.WriteString("\n" + e.trace.fullTrace)
-		}
+		pad(b, start, "trace: \n")
+		b.WriteString(e.trace.String())
+	}
+
+	if len(e.hops) > 0 {
+		pad(b, start, "return: ")
+		writeHops(b, e.hops)
 	}
 
 	if e.Errors != nil {
 		// Indent on new line if we are cascading non errors.
 		for idx := range e.Errors {
 			itm := e.Errors[idx]
-			pad(b, Separator)
-			b.WriteString(itm.Error())
+			if itm == nil {
+				continue
+			}
 
+			pad(b, start, Separator)
+			if containsError(path, itm) || sameErrors(itm.Errors, root) {
+				b.WriteString(cycleMessage)
+				continue
+			}
+			itm.format(b, root, append(path, itm))
 		}
 	}
 
-	if b.Len() == 0 {
-		return "no error"
+	if b.Len() == start {
+		b.WriteString("no error")
 	}
-	return b.String()
 }
 
 // StatusCode status code in Error
@@ -85,3 +116,49 @@ func (e Error) StatusCode() int {
 func (e Error) ToResponseError() ErrResponse {
 	return NewResponseError(e)
 }
+
+func writeMeta(b *bytes.Buffer, meta map[string]interface{}) {
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for idx, k := range keys {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(k + "=" + fmt.Sprint(meta[k]))
+	}
+}
+
+// containsError whether err is in errs, paths are short so a slice is cheaper than a map
+func containsError(errs []*Error, err *Error) bool {
+	for idx := range errs {
+		if errs[idx] == err {
+			return true
+		}
+	}
+	return false
+}
+
+// sameErrors whether a and b are the same slice, an error with the errors of
+// the root is the root itself, which is held by value and has no pointer in the path
+func sameErrors(a, b []*Error) bool {
+	return len(a) > 0 && len(a) == len(b) && &a[0] == &b[0]
+}
+
+// errorPath errors from the root to the current error to detect cycles
+type errorPath struct {
+	root []*Error
+	errs map[*Error]bool
+}
+
+func newErrorPath(root Error) errorPath {
+	return errorPath{root: root.Errors, errs: map[*Error]bool{}}
+}
+
+// has whether err is in the path, including the root
+func (p errorPath) has(err *Error) bool {
+	return p.errs[err] || sameErrors(err.Errors, p.root)
+}
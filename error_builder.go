@@ -14,6 +14,7 @@ type ErrorBuilder struct {
 	Target  string
 	Op      string
 	Errors  []*Error
+	Meta    map[string]interface{}
 }
 
 // Err make a error
@@ -24,6 +25,7 @@ func (e ErrorBuilder) Err() Error {
 		Target(e.Target),
 		Op(e.Op),
 		e.Errors,
+		Meta(e.Meta),
 		skipCaller(1),
 	)
 }
@@ -51,6 +53,9 @@ func New(args ...interface{}) ErrorBuilder {
 		case Code:
 			e.Code = int(arg)
 
+		case Meta:
+			e.Meta = mergeMeta(e.Meta, arg)
+
 		case *Error:
 			// Make a copy
 			copy := arg
@@ -0,0 +1,58 @@
+package gerr
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/dwarvesf/gerr/cleanpath"
+)
+
+func TestError_Error(t *testing.T) {
+	err := E(ErrIDInvalid, Target("id"), []Error{{Target: "raw", Message: "must be a number"}})
+	want := err.Error()
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+
+	copied := err
+	copied.Message = "id is invalid"
+	if got := copied.Error(); !strings.Contains(got, "message: id is invalid") {
+		t.Errorf("Error() after changing message = %v", got)
+	}
+
+	err.Errors[0].Message = "must be positive"
+	if got := err.Error(); !strings.Contains(got, "message: must be positive") {
+		t.Errorf("Error() after changing a child = %v", got)
+	}
+
+	withMeta := E(ErrIDInvalid, Meta{"orderId": 1})
+	_ = withMeta.Error()
+	withMeta.Meta["orderId"] = 2
+	if got := withMeta.Error(); !strings.Contains(got, "orderId=2") {
+		t.Errorf("Error() after changing meta = %v", got)
+	}
+
+	cycle := E(ErrIDInvalid, Target("cycle"))
+	cycle.Errors = []*Error{&cycle}
+	if first, second := cycle.Error(), cycle.Error(); first != second || !strings.Contains(first, cycleMessage) {
+		t.Errorf("Error() of a cycle = %v, then %v", first, second)
+	}
+}
+
+func TestError_traceRepoRoot(t *testing.T) {
+	defer cleanpath.SetRepoRoot(cleanpath.GetRepoRoot())
+	cleanpath.SetRepoRoot("")
+
+	_, file, _, _ := runtime.Caller(0)
+	err := E(ErrSvcTimeout)
+	if got := err.Error(); !strings.Contains(got, cleanpath.Clean(file)) {
+		t.Fatalf("Error() = %v, want %v", got, cleanpath.Clean(file))
+	}
+
+	cleanpath.SetRepoRoot(filepath.Dir(file))
+	if got := err.Error(); !strings.Contains(got, "\n\t"+filepath.Base(file)+":") {
+		t.Errorf("Error() after SetRepoRoot = %v, want relative path %v", got, filepath.Base(file))
+	}
+}
@@ -0,0 +1,277 @@
+package gerr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/dwarvesf/gerr/cleanpath"
+)
+
+// Frame a frame of a stack trace
+//
+// It is a program counter like Frame of github.com/pkg/errors,
+// Function, File, Line and Package are symbolized on demand
+type Frame uintptr
+
+// StackTrace frames of a stack, from the innermost frame
+//
+// It has the same layout as StackTrace of github.com/pkg/errors,
+// so error reporters looking for `StackTrace()` can read it
+type StackTrace []Frame
+
+// StackTrace frames of the stack where the error was made, from the innermost frame
+//
+// nil when the stack was not captured
+func (e Error) StackTrace() StackTrace {
+	if e.trace == nil {
+		return nil
+	}
+
+	rs := make(StackTrace, len(e.trace.pcs))
+	for idx, pc := range e.trace.pcs {
+		rs[idx] = Frame(pc)
+	}
+	return rs
+}
+
+// Callers program counters of the stack where the error was made, like runtime.Callers
+//
+// nil when the stack was not captured
+func (e Error) Callers() []uintptr {
+	if e.trace == nil {
+		return nil
+	}
+
+	rs := make([]uintptr, len(e.trace.pcs))
+	copy(rs, e.trace.pcs)
+	return rs
+}
+
+func (f Frame) frame() runtime.Frame {
+	frame, _ := runtime.CallersFrames([]uintptr{uintptr(f)}).Next()
+	return frame
+}
+
+// Function full function name, eg. "github.com/dwarvesf/gerr.(*Error).Error"
+func (f Frame) Function() string {
+	fn := f.frame().Function
+	if fn == "" {
+		return "unknown"
+	}
+	return fn
+}
+
+// File full path of the source file, see cleanpath.Clean for a short path
+func (f Frame) File() string {
+	file := f.frame().File
+	if file == "" {
+		return "unknown"
+	}
+	return file
+}
+
+// Line line number in the source file
+func (f Frame) Line() int {
+	return f.frame().Line
+}
+
+// Package import path of the function, eg. "github.com/dwarvesf/gerr"
+func (f Frame) Package() string {
+	return getPackageName(f.frame().Function)
+}
+
+// String format frame as "function file:line", the file is cleaned by cleanpath.Clean
+func (f Frame) String() string {
+	frame := f.frame()
+	return frame.Function + " " + cleanpath.Clean(frame.File) + ":" + strconv.Itoa(frame.Line)
+}
+
+// MarshalJSON make json of the frame with function, file, line and package
+//
+// The file is cleaned by cleanpath.Clean
+func (f Frame) MarshalJSON() ([]byte, error) {
+	frame := f.frame()
+	return json.Marshal(frameResponse{
+		Function: frame.Function,
+		File:     cleanpath.Clean(frame.File),
+		Line:     frame.Line,
+		Package:  getPackageName(frame.Function),
+	})
+}
+
+// Format format the frame like Frame of github.com/pkg/errors
+//
+//	%s    source file
+//	%d    source line
+//	%n    function name
+//	%v    equivalent to %s:%d
+//	%+s   function name and path of source file, separated by \n\t
+//	%+v   equivalent to %+s:%d
+func (f Frame) Format(s fmt.State, verb rune) {
+	frame := f.frame()
+	switch verb {
+	case 's':
+		if s.Flag('+') {
+			io.WriteString(s, frame.Function+"\n\t"+cleanpath.Clean(frame.File))
+			return
+		}
+		io.WriteString(s, path.Base(frame.File))
+	case 'd':
+		io.WriteString(s, strconv.Itoa(frame.Line))
+	case 'n':
+		io.WriteString(s, funcName(frame.Function))
+	case 'v':
+		f.Format(s, 's')
+		io.WriteString(s, ":")
+		f.Format(s, 'd')
+	}
+}
+
+// Format format the stack like StackTrace of github.com/pkg/errors
+//
+//	%s    source files of the frames
+//	%v    source files and lines of the frames
+//	%+v   function names, paths of source files and lines of the frames, one per line
+func (st StackTrace) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		switch {
+		case s.Flag('+'):
+			for _, f := range st {
+				io.WriteString(s, "\n")
+				f.Format(s, verb)
+			}
+		case s.Flag('#'):
+			fmt.Fprintf(s, "%#v", []Frame(st))
+		default:
+			st.formatSlice(s, verb)
+		}
+	case 's':
+		st.formatSlice(s, verb)
+	}
+}
+
+func (st StackTrace) formatSlice(s fmt.State, verb rune) {
+	io.WriteString(s, "[")
+	for idx, f := range st {
+		if idx > 0 {
+			io.WriteString(s, " ")
+		}
+		f.Format(s, verb)
+	}
+	io.WriteString(s, "]")
+}
+
+type frameResponse struct {
+	Function  string `json:"function"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Package   string `json:"package,omitempty"`
+	Collapsed int    `json:"collapsed,omitempty"`
+
+	// Source source code around the line, only in debug mode and not in production
+	Source []SourceLine `json:"source,omitempty"`
+}
+
+// MarshalJSON make json of the frames filtered by FrameFilter,
+// collapsed frames are an item with the number of frames
+//
+// Frames of the application have source code around their line
+// in debug mode and not in production, see ResponsePolicy
+func (st StackTrace) MarshalJSON() ([]byte, error) {
+	if st == nil {
+		return []byte("null"), nil
+	}
+
+	showsSource := responsePolicy.showsSource()
+	frames := filterFrames(symbolize(st))
+	rs := make([]frameResponse, len(frames))
+	for idx, frame := range frames {
+		if frame.collapsed > 0 {
+			rs[idx] = frameResponse{Function: frame.label(), Collapsed: frame.collapsed}
+			continue
+		}
+		rs[idx] = frameResponse{
+			Function: frame.Function,
+			File:     cleanpath.Clean(frame.File),
+			Line:     frame.Line,
+			Package:  getPackageName(frame.Function),
+		}
+		if showsSource && isAppFrame(frame.Function, frame.File) {
+			rs[idx].Source = getSourceLines(frame.File, frame.Line)
+		}
+	}
+	return json.Marshal(rs)
+}
+
+// FormatFrames format frames like runtime.Stack, files are cleaned by cleanpath.Clean
+// and frames are filtered by FrameFilter
+//
+//	github.com/dwarvesf/gerr.E
+//		github.com/dwarvesf/gerr@v1.0.0/init.go:40
+func FormatFrames(frames []Frame) string {
+	b := new(strings.Builder)
+	for idx, frame := range filterFrames(symbolize(frames)) {
+		if idx > 0 {
+			b.WriteString("\n")
+		}
+		writeFrame(b, frame)
+	}
+	return b.String()
+}
+
+func writeFrame(b *strings.Builder, frame filteredFrame) {
+	if frame.collapsed > 0 {
+		b.WriteString(frame.label())
+		return
+	}
+	writeFrameLines(b, frame.Function, frame.File, frame.Line)
+}
+
+// writeFrameLines write "function\n\tfile:line", pieces are written one by one to save allocations
+func writeFrameLines(b *strings.Builder, function, file string, line int) {
+	b.WriteString(function)
+	b.WriteString("\n\t")
+	writeFileLine(b, file, line)
+}
+
+// writeFileLine write "file:line", the file is cleaned by cleanpath.Clean
+func writeFileLine(b *strings.Builder, file string, line int) {
+	var num [20]byte
+	b.WriteString(cleanpath.Clean(file))
+	b.WriteByte(':')
+	b.Write(strconv.AppendInt(num[:0], int64(line), 10))
+}
+
+func symbolize(frames []Frame) []runtime.Frame {
+	rs := make([]runtime.Frame, len(frames))
+	for idx, f := range frames {
+		rs[idx] = f.frame()
+	}
+	return rs
+}
+
+// funcName function name without package, eg. "(*Error).Error"
+func funcName(fn string) string {
+	fn = fn[strings.LastIndex(fn, "/")+1:]
+	return fn[strings.Index(fn, ".")+1:]
+}
+
+// getPackageName import path of a full function name
+func getPackageName(fn string) string {
+	lastSlash := strings.LastIndex(fn, "/")
+	if lastSlash < 0 {
+		lastSlash = 0
+	}
+
+	dot := strings.Index(fn[lastSlash:], ".")
+	if dot < 0 {
+		return fn
+	}
+	return fn[:lastSlash+dot]
+}
@@ -0,0 +1,125 @@
+package gerr
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// FrameFilter filter of frames in printed stack traces: Error(), json of StackTrace and logs
+//
+// DropPackages: packages whose frames are dropped, eg. "net/http", "testing"
+// Module: module of the application, only its frames and main package are kept
+// CollapsePackages: packages of middleware, their consecutive frames are collapsed into one line
+//
+// Packages match their sub packages, eg. "net/http" matches "net/http/httptest".
+// StackTrace() and Callers() always return all frames
+type FrameFilter struct {
+	DropPackages     []string
+	Module           string
+	CollapsePackages []string
+}
+
+// frameFilterState filter with its generation, printed traces are cached for a generation
+type frameFilterState struct {
+	filter FrameFilter
+	gen    uint64
+}
+
+var (
+	frameFilterMu sync.Mutex
+	frameFilter   atomic.Value // frameFilterState
+)
+
+// SetFrameFilter set filter of frames in printed stack traces
+//
+// It is safe to call while errors are printed, traces printed before are printed again
+func SetFrameFilter(f FrameFilter) {
+	f.DropPackages = append([]string(nil), f.DropPackages...)
+	f.CollapsePackages = append([]string(nil), f.CollapsePackages...)
+
+	frameFilterMu.Lock()
+	defer frameFilterMu.Unlock()
+	frameFilter.Store(frameFilterState{filter: f, gen: loadFrameFilter().gen + 1})
+}
+
+// GetFrameFilter get current filter of frames
+func GetFrameFilter() FrameFilter {
+	return loadFrameFilter().filter
+}
+
+func loadFrameFilter() frameFilterState {
+	state, _ := frameFilter.Load().(frameFilterState)
+	return state
+}
+
+// filteredFrame frame or run of collapsed frames
+type filteredFrame struct {
+	runtime.Frame
+	collapsed int
+}
+
+// label text of collapsed frames, eg. "… 7 middleware frames"
+func (f filteredFrame) label() string {
+	if f.collapsed == 1 {
+		return "… 1 middleware frame"
+	}
+	return "… " + strconv.Itoa(f.collapsed) + " middleware frames"
+}
+
+func (f FrameFilter) isZero() bool {
+	return len(f.DropPackages) == 0 && f.Module == "" && len(f.CollapsePackages) == 0
+}
+
+func (f FrameFilter) apply(frames []runtime.Frame) []filteredFrame {
+	rs := make([]filteredFrame, 0, len(frames))
+	for idx := range frames {
+		pkg := getPackageName(frames[idx].Function)
+
+		switch {
+		case matchPackages(pkg, f.CollapsePackages):
+			if last := len(rs) - 1; last >= 0 && rs[last].collapsed > 0 {
+				rs[last].collapsed++
+				continue
+			}
+			rs = append(rs, filteredFrame{collapsed: 1})
+
+		case matchPackages(pkg, f.DropPackages):
+		case f.Module != "" && pkg != "main" && !matchPackage(pkg, f.Module):
+
+		default:
+			rs = append(rs, filteredFrame{Frame: frames[idx]})
+		}
+	}
+	return rs
+}
+
+func matchPackages(pkg string, prefixes []string) bool {
+	for idx := range prefixes {
+		if matchPackage(pkg, prefixes[idx]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPackage whether pkg is the package prefix or one of its sub packages
+func matchPackage(pkg, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return pkg == prefix || strings.HasPrefix(pkg, prefix+"/")
+}
+
+// filterFrames apply the current filter to frames
+func filterFrames(frames []runtime.Frame) []filteredFrame {
+	f := loadFrameFilter().filter
+	if f.isZero() {
+		rs := make([]filteredFrame, len(frames))
+		for idx := range frames {
+			rs[idx].Frame = frames[idx]
+		}
+		return rs
+	}
+	return f.apply(frames)
+}
@@ -0,0 +1,113 @@
+package gerr
+
+import (
+	"encoding/json"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFrameFilter_apply(t *testing.T) {
+	frames := []runtime.Frame{
+		{Function: "github.com/app/repo.(*Order).Get"},
+		{Function: "github.com/app/api.Handler"},
+		{Function: "github.com/gin-gonic/gin.(*Context).Next"},
+		{Function: "github.com/app/middleware.Auth.func1"},
+		{Function: "github.com/gin-gonic/gin.(*Context).Next"},
+		{Function: "net/http.HandlerFunc.ServeHTTP"},
+		{Function: "github.com/gin-gonic/gin.(*Engine).ServeHTTP"},
+		{Function: "net/http.serverHandler.ServeHTTP"},
+		{Function: "main.main"},
+		{Function: "runtime.goexit"},
+	}
+
+	tests := []struct {
+		name   string
+		filter FrameFilter
+		want   []filteredFrame
+	}{
+		{
+			name:   "drop packages",
+			filter: FrameFilter{DropPackages: []string{"net/http", "github.com/gin-gonic/gin", "runtime"}},
+			want: []filteredFrame{
+				{Frame: frames[0]},
+				{Frame: frames[1]},
+				{Frame: frames[3]},
+				{Frame: frames[8]},
+			},
+		},
+		{
+			name:   "keep module",
+			filter: FrameFilter{Module: "github.com/app"},
+			want: []filteredFrame{
+				{Frame: frames[0]},
+				{Frame: frames[1]},
+				{Frame: frames[3]},
+				{Frame: frames[8]},
+			},
+		},
+		{
+			name: "collapse middleware",
+			filter: FrameFilter{
+				DropPackages:     []string{"net/http"},
+				CollapsePackages: []string{"github.com/gin-gonic/gin", "github.com/app/middleware"},
+			},
+			want: []filteredFrame{
+				{Frame: frames[0]},
+				{Frame: frames[1]},
+				{collapsed: 4},
+				{Frame: frames[8]},
+				{Frame: frames[9]},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.apply(frames); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FrameFilter.apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetFrameFilter(t *testing.T) {
+	defer SetFrameFilter(GetFrameFilter())
+
+	err := E(ErrSvcTimeout)
+	if !strings.Contains(err.Error(), "testing.tRunner") {
+		t.Fatalf("Error() = %v, want testing frames", err.Error())
+	}
+
+	SetFrameFilter(FrameFilter{DropPackages: []string{"runtime"}, CollapsePackages: []string{"testing"}})
+	if got := err.Error(); strings.Contains(got, "testing.tRunner") || !strings.Contains(got, "… 1 middleware frame") {
+		t.Errorf("Error() = %v", got)
+	}
+
+	b, _ := json.Marshal(err.StackTrace())
+	var got []frameResponse
+	if jsonErr := json.Unmarshal(b, &got); jsonErr != nil || len(got) != 2 || got[1].Collapsed != 1 {
+		t.Errorf("MarshalJSON() = %s", b)
+	}
+
+	if frames := err.StackTrace(); len(frames) < 3 {
+		t.Errorf("StackTrace() = %v, want all frames", frames)
+	}
+}
+
+func TestSetFrameFilter_concurrent(t *testing.T) {
+	defer SetFrameFilter(GetFrameFilter())
+
+	err := E(ErrSvcTimeout)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = err.Error()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		SetFrameFilter(FrameFilter{DropPackages: []string{"runtime"}})
+	}
+	<-done
+}
@@ -0,0 +1,112 @@
+package gerr
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dwarvesf/gerr/cleanpath"
+)
+
+func TestError_StackTrace(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	err := E(ErrSvcTimeout)
+	line++
+
+	frames := err.StackTrace()
+	if len(frames) == 0 {
+		t.Fatalf("StackTrace() is empty")
+	}
+
+	f := frames[0]
+	if f.Function() != "github.com/dwarvesf/gerr.TestError_StackTrace" {
+		t.Errorf("Function() = %v", f.Function())
+	}
+	if f.Package() != "github.com/dwarvesf/gerr" {
+		t.Errorf("Package() = %v", f.Package())
+	}
+	if f.File() != file || f.Line() != line {
+		t.Errorf("File(), Line() = %v:%v, want %v:%v", f.File(), f.Line(), file, line)
+	}
+
+	if !strings.HasPrefix(FormatFrames(frames), "github.com/dwarvesf/gerr.TestError_StackTrace\n\t"+cleanpath.Clean(file)) {
+		t.Errorf("FormatFrames() = %v", FormatFrames(frames))
+	}
+
+	var got frameResponse
+	b, _ := json.Marshal(f)
+	if jsonErr := json.Unmarshal(b, &got); jsonErr != nil || got.Line != line || got.Package != "github.com/dwarvesf/gerr" {
+		t.Errorf("MarshalJSON() = %s", b)
+	}
+
+	if frames := (Error{}).StackTrace(); frames != nil {
+		t.Errorf("StackTrace() = %v, want nil", frames)
+	}
+}
+
+func Test_getPackageName(t *testing.T) {
+	tests := map[string]string{
+		"github.com/dwarvesf/gerr.(*Error).Error": "github.com/dwarvesf/gerr",
+		"github.com/dwarvesf/gerr.E.func1":        "github.com/dwarvesf/gerr",
+		"main.main":                               "main",
+		"net/http.HandlerFunc.ServeHTTP":          "net/http",
+	}
+	for fn, want := range tests {
+		if got := getPackageName(fn); got != want {
+			t.Errorf("getPackageName(%v) = %v, want %v", fn, got, want)
+		}
+	}
+}
+
+func TestStackTrace_Format(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	st := E(ErrSvcTimeout).StackTrace()
+	line++
+
+	f := st[0]
+	tests := map[string]string{
+		"%s":  "frame_test.go",
+		"%d":  strconv.Itoa(line),
+		"%n":  "TestStackTrace_Format",
+		"%v":  "frame_test.go:" + strconv.Itoa(line),
+		"%+v": "github.com/dwarvesf/gerr.TestStackTrace_Format\n\t" + cleanpath.Clean(file) + ":" + strconv.Itoa(line),
+	}
+	for format, want := range tests {
+		if got := fmt.Sprintf(format, f); got != want {
+			t.Errorf("Sprintf(%v) = %v, want %v", format, got, want)
+		}
+	}
+
+	if got := fmt.Sprintf("%+v", st); !strings.HasPrefix(got, "\n"+tests["%+v"]+"\n") {
+		t.Errorf("Sprintf(%%+v) = %v", got)
+	}
+	if got := fmt.Sprintf("%v", st[:1]); got != "["+tests["%v"]+"]" {
+		t.Errorf("Sprintf(%%v) = %v", got)
+	}
+}
+
+// stackTracer interface of errors in github.com/pkg/errors, reporters read frames by reflection
+func TestError_stackTracer(t *testing.T) {
+	var err error = E(ErrSvcTimeout)
+
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		t.Fatalf("StackTrace() is not found")
+	}
+	frames := method.Call(nil)[0]
+	if frames.Len() == 0 || frames.Index(0).Kind() != reflect.Uintptr {
+		t.Errorf("StackTrace() = %v, want frames of uintptr", frames)
+	}
+
+	callers, ok := err.(interface{ Callers() []uintptr })
+	if !ok {
+		t.Fatalf("Callers() is not found")
+	}
+	if pcs := callers.Callers(); len(pcs) != frames.Len() || pcs[0] != uintptr(frames.Index(0).Uint()) {
+		t.Errorf("Callers() = %v", pcs)
+	}
+}
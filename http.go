@@ -4,14 +4,22 @@ package gerr
 type ErrDetailResponse map[string]interface{}
 
 // ErrResponse error presentation
+//
+// ReferenceID: support reference for hidden internal errors
 type ErrResponse struct {
-	Message string            `json:"message,omitempty"`
-	Errors  ErrDetailResponse `json:"errors,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Errors      ErrDetailResponse `json:"errors,omitempty"`
+	ReferenceID string            `json:"referenceId,omitempty"`
 }
 
 // NewResponseError make err response from system Error
+//
+// Internal and service errors and errors without code are hidden behind a public message
+// and a reference id unless debug mode is on, so are messages of internal and service children,
+// see ResponsePolicy.
+// The errors tree is truncated by ResponseLimits
 func NewResponseError(err Error) ErrResponse {
-	return doMakeErrResponse(err)
+	return responsePolicy.apply(err, doMakeErrResponse(responsePolicy.redact(responseLimits.apply(err))))
 }
 
 func doMakeErrResponse(err Error) ErrResponse {
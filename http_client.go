@@ -0,0 +1,101 @@
+package gerr
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	// HeaderTraceID header carries the trace id between services
+	HeaderTraceID = "X-Trace-Id"
+
+	// HeaderService header carries the name of the service which made the response
+	HeaderService = "X-Service-Name"
+
+	// MetaKeyService meta key for the remote service name
+	MetaKeyService = "service"
+)
+
+// maxErrResponseSize limit of an error body which is read from remote service
+const maxErrResponseSize = 1 << 20
+
+type transport struct {
+	base http.RoundTripper
+}
+
+// Transport make a round tripper which decodes error responses (4xx and 5xx) into Error
+//
+// 1xx, 2xx and 3xx responses are returned as they are, so http.Client still follows
+// redirects and handles 304 responses. http.DefaultTransport is used when base is nil.
+//
+// NOTE: unlike the http.RoundTripper contract, an error response is returned as an error
+// and a nil response, its body is consumed and closed.
+// http.Client wraps the returned error in *url.Error, use errors.As to get the Error
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := DecodeResponse(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DecodeResponse make Error from an error response (4xx and 5xx) of a gerr service
+//
+// nil is returned for other responses and the body is left untouched.
+// Otherwise the body is consumed and closed.
+func DecodeResponse(resp *http.Response) error {
+	if resp == nil || !isErrorStatus(resp.StatusCode) {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	rs := Error{
+		Code:    resp.StatusCode,
+		TraceID: resp.Header.Get(HeaderTraceID),
+	}
+
+	if svc := getRemoteService(resp); svc != "" {
+		rs.Meta = map[string]interface{}{MetaKeyService: svc}
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrResponseSize))
+	if err == nil {
+		if parsed, err := ParseResponse(body); err == nil {
+			rs.Message = parsed.Message
+			rs.Errors = parsed.Errors
+			rs.Meta = mergeMeta(rs.Meta, parsed.Meta)
+		}
+	}
+
+	if rs.Message == "" {
+		rs.Message = http.StatusText(resp.StatusCode)
+	}
+	return rs
+}
+
+func isErrorStatus(code int) bool {
+	return code >= http.StatusBadRequest
+}
+
+func getRemoteService(resp *http.Response) string {
+	if svc := resp.Header.Get(HeaderService); svc != "" {
+		return svc
+	}
+
+	if resp.Request != nil && resp.Request.URL != nil {
+		return resp.Request.URL.Host
+	}
+	return ""
+}
@@ -0,0 +1,166 @@
+package gerr
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		header  map[string]string
+		body    string
+		want    error
+		wantSvc bool
+	}{
+		{
+			name:   "success response",
+			status: http.StatusOK,
+			body:   `{"id": 1}`,
+			want:   nil,
+		},
+		{
+			name:   "redirect response",
+			status: http.StatusFound,
+			want:   nil,
+		},
+		{
+			name:   "error response with details",
+			status: http.StatusBadRequest,
+			header: map[string]string{
+				HeaderTraceID: "abc123",
+				HeaderService: "order",
+			},
+			body: `{"message":"message error","errors":{"items":{"0":{"amount":["out of stock"]},"1":{"id":["not found","invalid"]}}}}`,
+			want: Error{
+				TraceID: "abc123",
+				Code:    http.StatusBadRequest,
+				Message: "message error",
+				Meta:    map[string]interface{}{MetaKeyService: "order"},
+				Errors: []*Error{
+					{
+						Target: "items",
+						Errors: []*Error{
+							{
+								Target: "0",
+								Errors: []*Error{
+									{Target: "amount", Message: "out of stock"},
+								},
+							},
+							{
+								Target: "1",
+								Errors: []*Error{
+									{
+										Target: "id",
+										Errors: []*Error{
+											{Message: "not found"},
+											{Message: "invalid"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "error response without body",
+			status:  http.StatusBadGateway,
+			body:    `upstream is down`,
+			wantSvc: true,
+			want: Error{
+				Code:    http.StatusBadGateway,
+				Message: "Bad Gateway",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			resp, err := http.Get(srv.URL)
+			if err != nil {
+				t.Fatalf("http.Get() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			want := tt.want
+			if tt.wantSvc {
+				u, _ := url.Parse(srv.URL)
+				e := want.(Error)
+				e.Meta = map[string]interface{}{MetaKeyService: u.Host}
+				want = e
+			}
+
+			if got := DecodeResponse(resp); !reflect.DeepEqual(got, want) {
+				t.Errorf("DecodeResponse() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"record not found"}`))
+	}))
+	defer srv.Close()
+
+	client := http.Client{Transport: Transport(nil)}
+	_, err := client.Get(srv.URL)
+
+	var e Error
+	if !errors.As(err, &e) {
+		t.Fatalf("client.Get() error = %v, want Error", err)
+	}
+	if e.Code != http.StatusNotFound || e.Message != "record not found" {
+		t.Errorf("client.Get() error = %v", e)
+	}
+}
+
+func TestTransport_redirect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusFound)
+		case "/cached":
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	client := http.Client{Transport: Transport(nil)}
+	resp, err := client.Get(srv.URL + "/old")
+	if err != nil {
+		t.Fatalf("client.Get() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Request.URL.Path != "/new" {
+		t.Errorf("client.Get() = %v %v, want redirect to /new", resp.StatusCode, resp.Request.URL.Path)
+	}
+
+	resp, err = client.Get(srv.URL + "/cached")
+	if err != nil {
+		t.Fatalf("client.Get() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("client.Get() = %v, want %v", resp.StatusCode, http.StatusNotModified)
+	}
+}
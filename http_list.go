@@ -0,0 +1,65 @@
+package gerr
+
+// ErrItemResponse error detail item
+//
+// Path: targets from the root to the error in dotted-bracket syntax, eg. items[0].productId
+type ErrItemResponse struct {
+	Path    string `json:"path"`
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// ErrListResponse error presentation with ordered error details
+//
+// Unlike ErrResponse, details keep the order of Errors
+// and every message in the tree is returned
+type ErrListResponse struct {
+	Message     string            `json:"message,omitempty"`
+	Errors      []ErrItemResponse `json:"errors,omitempty"`
+	ReferenceID string            `json:"referenceId,omitempty"`
+}
+
+// NewListResponseError make list err response from system Error
+//
+// The errors tree is truncated by ResponseLimits
+func NewListResponseError(err Error) ErrListResponse {
+	return responsePolicy.applyList(err, doMakeErrListResponse(responsePolicy.redact(responseLimits.apply(err))))
+}
+
+// ToListResponseError make list response err
+func (e Error) ToListResponseError() ErrListResponse {
+	return NewListResponseError(e)
+}
+
+func doMakeErrListResponse(err Error) ErrListResponse {
+	return ErrListResponse{
+		Message: err.Message,
+		Errors:  doMakeErrItems(err.Errors, nil, nil),
+	}
+}
+
+// doMakeErrItems walk errors in depth-first order
+func doMakeErrItems(errs []*Error, keys []string, rs []ErrItemResponse) []ErrItemResponse {
+	for idx := range errs {
+		itm := errs[idx]
+		if itm == nil {
+			continue
+		}
+
+		currKeys := keys
+		if itm.Target != "" {
+			currKeys = append(keys[:len(keys):len(keys)], itm.Target)
+		}
+
+		if itm.Message != "" {
+			rs = append(rs, ErrItemResponse{
+				Path:    FormatPath(currKeys, PathDotted),
+				Code:    itm.Code,
+				Message: itm.Message,
+			})
+		}
+
+		rs = doMakeErrItems(itm.Errors, currKeys, rs)
+	}
+	return rs
+}
@@ -0,0 +1,132 @@
+package gerr
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// MetaKeyReferenceID meta key for the support reference id of a response
+const MetaKeyReferenceID = "referenceId"
+
+type rawErrResponse struct {
+	Message     string      `json:"message"`
+	Errors      interface{} `json:"errors"`
+	ReferenceID string      `json:"referenceId"`
+}
+
+// ParseResponse make Error from a response body, the inverse of NewResponseError
+//
+// Both ErrResponse and ErrListResponse bodies are supported.
+// Arrays of messages become multiple leaf children of their target.
+func ParseResponse(data []byte) (Error, error) {
+	var raw rawErrResponse
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Error{}, err
+	}
+
+	rs := Error{Message: raw.Message}
+	if raw.ReferenceID != "" {
+		rs.Meta = map[string]interface{}{MetaKeyReferenceID: raw.ReferenceID}
+	}
+
+	switch errs := raw.Errors.(type) {
+	case map[string]interface{}:
+		rs.Errors = doMakeErrorsFromDetails(errs)
+
+	case []interface{}:
+		doMakeErrorsFromItems(errs, &rs)
+
+	case nil:
+
+	default:
+		return Error{}, fmt.Errorf("gerr: unexpected errors type %T in response", errs)
+	}
+	return rs, nil
+}
+
+// doMakeErrorsFromItems rebuild errors from ErrListResponse items
+func doMakeErrorsFromItems(items []interface{}, rs *Error) {
+	for idx := range items {
+		itm, ok := items[idx].(map[string]interface{})
+		if !ok {
+			rs.Errors = append(rs.Errors, doMakeErrorFromDetail("", items[idx]))
+			continue
+		}
+
+		path, _ := itm["path"].(string)
+		msg, _ := itm["message"].(string)
+		code, _ := itm["code"].(float64)
+
+		keys, err := ParsePath(path)
+		if err != nil {
+			keys = []string{path}
+		}
+
+		var node *Error
+		if len(keys) == 0 {
+			node = &Error{Message: msg}
+			rs.Errors = append(rs.Errors, node)
+		} else {
+			node = doMakeChildren(keys, msg, rs)
+		}
+		if node != nil {
+			node.Code = int(code)
+		}
+	}
+}
+
+// doMakeErrorsFromDetails make error children from response error details
+// keys are sorted for a stable order
+func doMakeErrorsFromDetails(details map[string]interface{}) []*Error {
+	if len(details) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(details))
+	for k := range details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rs := make([]*Error, 0, len(keys))
+	for _, k := range keys {
+		rs = append(rs, doMakeErrorFromDetail(k, details[k]))
+	}
+	return rs
+}
+
+func doMakeErrorFromDetail(target string, val interface{}) *Error {
+	switch val := val.(type) {
+	case nil:
+		return &Error{Target: target}
+
+	case string:
+		return &Error{Target: target, Message: val}
+
+	case map[string]interface{}:
+		return &Error{Target: target, Errors: doMakeErrorsFromDetails(val)}
+
+	case []interface{}:
+		// A single message is a leaf
+		if len(val) == 1 {
+			if msg, ok := val[0].(string); ok {
+				return &Error{Target: target, Message: msg}
+			}
+		}
+
+		rs := &Error{Target: target}
+		for idx := range val {
+			childTarget := ""
+			if _, ok := val[idx].(string); !ok {
+				childTarget = strconv.Itoa(idx)
+			}
+			rs.Errors = append(rs.Errors, doMakeErrorFromDetail(childTarget, val[idx]))
+		}
+		return rs
+
+	default:
+		return &Error{Target: target, Message: fmt.Sprint(val)}
+	}
+}
@@ -0,0 +1,120 @@
+package gerr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Error
+		wantErr bool
+	}{
+		{
+			name: "will parse nested errors",
+			data: `{"message":"message error","errors":{"items":{"0":{"amount":["out of stock"]},"1":{"id":["not found","invalid"]}}},"referenceId":"ref-1"}`,
+			want: Error{
+				Message: "message error",
+				Meta:    map[string]interface{}{MetaKeyReferenceID: "ref-1"},
+				Errors: []*Error{
+					{
+						Target: "items",
+						Errors: []*Error{
+							{
+								Target: "0",
+								Errors: []*Error{
+									{Target: "amount", Message: "out of stock"},
+								},
+							},
+							{
+								Target: "1",
+								Errors: []*Error{
+									{
+										Target: "id",
+										Errors: []*Error{
+											{Message: "not found"},
+											{Message: "invalid"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "will parse array of objects",
+			data: `{"message":"message error","errors":{"items":[{"amount":["out of stock"]}]}}`,
+			want: Error{
+				Message: "message error",
+				Errors: []*Error{
+					{
+						Target: "items",
+						Errors: []*Error{
+							{
+								Target: "0",
+								Errors: []*Error{
+									{Target: "amount", Message: "out of stock"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "will parse list errors",
+			data: `{"message":"message error","errors":[{"path":"items","message":"items got error"},{"path":"items[0].amount","code":20006,"message":"out of stock"},{"path":"","message":"error without target"}]}`,
+			want: Error{
+				Message: "message error",
+				Errors: []*Error{
+					{
+						Target:  "items",
+						Message: "items got error",
+						Errors: []*Error{
+							{
+								Target: "0",
+								Errors: []*Error{
+									{Target: "amount", Code: 20006, Message: "out of stock"},
+								},
+							},
+						},
+					},
+					{Message: "error without target"},
+				},
+			},
+		},
+		{
+			name:    "will return error for invalid body",
+			data:    `not json`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResponse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError_Flatten(t *testing.T) {
+	err, _ := ParseResponse([]byte(`{"errors":{"items":{"0":{"amount":["out of stock"]},"1":{"id":["not found","invalid"]}}}}`))
+
+	want := []CombinedItem{
+		{Keys: []string{"items", "0", "amount"}, Message: "out of stock"},
+		{Keys: []string{"items", "1", "id"}, Message: "not found"},
+		{Keys: []string{"items", "1", "id"}, Message: "invalid"},
+	}
+	if got := err.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten() = %v, want %v", got, want)
+	}
+}
@@ -103,3 +103,136 @@ func Test_doMakeErrResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestNewResponseError(t *testing.T) {
+	defer SetResponsePolicy(GetResponsePolicy())
+
+	tests := []struct {
+		name   string
+		policy ResponsePolicy
+		err    Error
+		want   ErrResponse
+	}{
+		{
+			name:   "will return business message",
+			policy: ResponsePolicy{},
+			err:    Error{Code: ErrRecordNotFound, Message: "record not found"},
+			want:   ErrResponse{Message: "record not found", Errors: map[string]interface{}{}},
+		},
+		{
+			name:   "will hide service message",
+			policy: ResponsePolicy{},
+			err: Error{
+				TraceID: "abc123",
+				Code:    ErrSvcLostConnection,
+				Message: "lost connection",
+				Errors:  []*Error{{Target: "db", Message: "dial tcp 10.0.0.1:5432"}},
+			},
+			want: ErrResponse{Message: "Internal Server Error", ReferenceID: "abc123"},
+		},
+		{
+			name: "will hide internal message with custom policy",
+			policy: ResponsePolicy{
+				PublicMessage: "something went wrong",
+				ReferenceID:   func(Error) string { return "ref-1" },
+			},
+			err:  Error{Code: ErrIOReadFailed, Message: "read failed"},
+			want: ErrResponse{Message: "something went wrong", ReferenceID: "ref-1"},
+		},
+		{
+			name:   "will hide message of error without code",
+			policy: ResponsePolicy{},
+			err:    E("pq: password authentication failed for user admin"),
+			want:   ErrResponse{Message: "Internal Server Error"},
+		},
+		{
+			name:   "will hide service child of client error",
+			policy: ResponsePolicy{},
+			err: E(400, E(ErrSvcLostConnection, "dial tcp 10.0.0.5:5432: connection refused", Target("db")),
+				E("name is required", Target("name"))),
+			want: ErrResponse{
+				Message: "Bad Request",
+				Errors: map[string]interface{}{
+					"db":   []interface{}{"Internal Server Error"},
+					"name": []interface{}{"name is required"},
+				},
+			},
+		},
+		{
+			name:   "will use reference id in meta",
+			policy: ResponsePolicy{},
+			err:    Error{Code: ErrIOReadFailed, Message: "read failed", Meta: map[string]interface{}{MetaKeyReferenceID: "ref-2"}},
+			want:   ErrResponse{Message: "Internal Server Error", ReferenceID: "ref-2"},
+		},
+		{
+			name:   "will reveal internal message in debug mode",
+			policy: ResponsePolicy{Debug: true},
+			err:    Error{Code: ErrIOReadFailed, Message: "read failed"},
+			want:   ErrResponse{Message: "read failed", Errors: map[string]interface{}{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetResponsePolicy(tt.policy)
+			if got := NewResponseError(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewResponseError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_doMakeErrListResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		err  Error
+		want ErrListResponse
+	}{
+		{
+			name: "will keep order and every message",
+			err: Error{
+				Message: "message error",
+				Errors: []*Error{
+					{Target: "field2", Message: "error field2"},
+					{
+						Target:  "items",
+						Message: "items got error",
+						Errors: []*Error{
+							{
+								Target: "0",
+								Errors: []*Error{
+									{Target: "amount", Code: ErrIDInvalid, Message: "out of stock"},
+								},
+							},
+						},
+					},
+					{Target: "field1", Message: "error field1"},
+					{Message: "error without target"},
+					{
+						Target: "items",
+						Errors: []*Error{
+							{Message: "not found"},
+						},
+					},
+				},
+			},
+			want: ErrListResponse{
+				Message: "message error",
+				Errors: []ErrItemResponse{
+					{Path: "field2", Message: "error field2"},
+					{Path: "items", Message: "items got error"},
+					{Path: "items[0].amount", Code: ErrIDInvalid, Message: "out of stock"},
+					{Path: "field1", Message: "error field1"},
+					{Path: "", Message: "error without target"},
+					{Path: "items", Message: "not found"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := doMakeErrListResponse(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("doMakeErrListResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -32,6 +32,11 @@ type TraceID string
 // string
 type Op string
 
+// Meta additional information for an error
+//
+// map[string]interface{}
+type Meta map[string]interface{}
+
 type skipCaller int
 
 // E builds an error value from its arguments.
@@ -40,6 +45,8 @@ func E(args ...interface{}) Error {
 		panic("call to errors.E with no arguments")
 	}
 	skip := 1
+	var base *Error
+	op := ""
 
 	e := Error{}
 	for _, arg := range args {
@@ -52,6 +59,7 @@ func E(args ...interface{}) Error {
 
 		case Op:
 			e.Op = string(arg)
+			op = e.Op
 
 		case string:
 			e.Message = arg
@@ -62,11 +70,18 @@ func E(args ...interface{}) Error {
 		case Code:
 			e.Code = int(arg)
 
+		case Meta:
+			e.Meta = mergeMeta(e.Meta, arg)
+
 		case *Error:
+			if arg == nil {
+				break
+			}
 			// Make a copy
 			copy := arg
 			if e.Code <= 0 {
-				e = *copy
+				e = wrapError(*copy, e)
+				base = copy
 				break
 			}
 			e.Errors = append(e.Errors, copy)
@@ -75,7 +90,8 @@ func E(args ...interface{}) Error {
 			// Make a copy
 			copy := arg
 			if e.Code <= 0 {
-				e = copy
+				e = wrapError(copy, e)
+				base = &copy
 				break
 			}
 			e.Errors = append(e.Errors, &copy)
@@ -98,7 +114,8 @@ func E(args ...interface{}) Error {
 
 		default:
 			_, file, line, _ := runtime.Caller(1)
-			log.Printf("errors.E: bad call from %s:%d: %v", file, line, args)
+			// copy args, they do not escape to the heap on good calls
+			log.Printf("errors.E: bad call from %s:%d: %v", file, line, append([]interface{}{}, args...))
 			msg := fmt.Sprintf("unknown type %T, value %v in error call", arg, arg)
 			errChild := Error{Message: msg}
 			e.Errors = append(e.Errors, &errChild)
@@ -110,53 +127,82 @@ func E(args ...interface{}) Error {
 	}
 
 	if e.Op == "" {
-		op := getStackTrace(skip)
-		e.Op = op.function
-		e.trace = &op
+		e.Op, e.trace = getStackTrace(skip, e.Code)
+	} else if base != nil {
+		e.hops = appendHop(e.hops, skip, op, *base, e.Message)
 	}
 
 	return e
 }
 
-func getStackTrace(skip int) stacktrace {
-	skip = skip + 1
-	buf := make([]byte, 1<<16)
-	stackSize := runtime.Stack(buf, true)
-	stackStr := string(buf[0:stackSize])
-
-	pc, file, line, ok := runtime.Caller(skip)
-	if !ok {
-		return newStackTrace("", 0, "?", stackStr)
+// wrapError make err the base of a wrapping error,
+// fields set by previous arguments are kept, the replaced op and message are kept in hops
+func wrapError(err Error, prev Error) Error {
+	if prev.TraceID != "" {
+		err.TraceID = prev.TraceID
+	}
+	if prev.Target != "" {
+		err.Target = prev.Target
+	}
+	if prev.Message != "" {
+		err.Message = prev.Message
+	}
+	if prev.Op != "" {
+		err.Op = prev.Op
+	}
+	if len(prev.Meta) > 0 {
+		err.Meta = mergeMeta(err.Meta, Meta(prev.Meta))
 	}
+	if len(prev.Errors) > 0 {
+		err.Errors = append(err.Errors[:len(err.Errors):len(err.Errors)], prev.Errors...)
+	}
+	return err
+}
 
-	fn := runtime.FuncForPC(pc)
-	fnName := ""
-	if fn != nil {
+func mergeMeta(dst map[string]interface{}, src Meta) map[string]interface{} {
+	if len(src) == 0 {
+		return dst
+	}
 
-		fnTemp := shortFuncName(fn)
-		if fnTemp != "" {
-			fnName = fnTemp
-		}
+	rs := make(map[string]interface{}, len(dst)+len(src))
+	for k := range dst {
+		rs[k] = dst[k]
+	}
+	for k := range src {
+		rs[k] = src[k]
+	}
+	return rs
+}
 
-		longName := fn.Name()
-		stackStr = stackStr[strings.LastIndex(stackStr, longName):]
-		if fnTemp == "init" {
-			stackStr = ""
-			fnName = ""
+// getStackTrace get function name of the caller and capture its stack by StackPolicy
+//
+// Only program counters of the current goroutine are captured,
+// errors made in init functions have no stack
+func getStackTrace(skip int, code int) (string, *stacktrace) {
+	policy := stackPolicy
+
+	if !policy.captures(code) {
+		fnName := getCallerNameFast(skip + 1)
+		if fnName == "init" || strings.HasPrefix(fnName, "init.") {
+			return "", nil
 		}
+		return fnName, nil
 	}
 
-	return newStackTrace(file, line, fnName, stackStr)
+	pcs := captureStack(skip+1, policy.depth())
+	fnName := getCallerName(pcs)
+	if fnName == "init" || strings.HasPrefix(fnName, "init.") {
+		return "", nil
+	}
+	return fnName, newStackTrace(pcs)
 }
 
 /* "FuncName" or "Receiver.MethodName" */
-func shortFuncName(f *runtime.Func) string {
-	// f.Name() is like one of these:
+func shortFuncName(longName string) string {
+	// longName is like one of these:
 	// - "github.com/palantir/shield/package.FuncName"
 	// - "github.com/palantir/shield/package.Receiver.MethodName"
 	// - "github.com/palantir/shield/package.(*PtrReceiver).MethodName"
-	longName := f.Name()
-
 	withoutPath := longName[strings.LastIndex(longName, "/")+1:]
 	withoutPackage := withoutPath[strings.Index(withoutPath, ".")+1:]
 
@@ -222,3 +222,66 @@ func TestEt(t *testing.T) {
 		})
 	}
 }
+
+func TestE_allocs(t *testing.T) {
+	defer SetStackPolicy(GetStackPolicy())
+	SetStackPolicy(StackPolicy{Categories: DefaultStackCategories()})
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = E(ErrIDInvalid, Target("id"))
+	})
+	if allocs > 0 {
+		t.Errorf("E() of business error allocates %v times, want 0", allocs)
+	}
+
+	if raceEnabled {
+		return
+	}
+
+	// the stack trace is printed once, then only the string is allocated
+	err := E(ErrSvcLostConnection, "lost connection")
+	_ = err.Error()
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = err.Error()
+	})
+	if allocs > 1 {
+		t.Errorf("Error() of a printed error allocates %v times, want 1", allocs)
+	}
+}
+
+func BenchmarkE(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = E(ErrSvcLostConnection, "lost connection")
+	}
+}
+
+func BenchmarkE_Error(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = E(ErrSvcLostConnection, "lost connection").Error()
+	}
+}
+
+func BenchmarkE_business(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = E(ErrIDInvalid, Target("id"))
+	}
+}
+
+func BenchmarkE_business_Error(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = E(ErrIDInvalid, Target("id")).Error()
+	}
+}
+
+func BenchmarkError_Error(b *testing.B) {
+	err := E(ErrSvcLostConnection, "lost connection", []Error{E(ErrIDInvalid, Target("id"))})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
@@ -0,0 +1,133 @@
+package gerr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MessageInvalidJSON message for invalid json body
+const MessageInvalidJSON = "invalid JSON body"
+
+const unknownFieldPrefix = "json: unknown field "
+
+// FromJSONError make Error from an error of json decoding
+//
+// Type mismatches and unknown fields become children with target of the field.
+// Other errors are returned as they are.
+func FromJSONError(err error) error {
+	return FromJSONErrorWithInput(err, nil)
+}
+
+// FromJSONErrorWithInput make Error from an error of json decoding,
+// input is used to find line and column of syntax errors
+func FromJSONErrorWithInput(err error, input []byte) error {
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+
+	switch {
+	case errors.As(err, &typeErr):
+		msg := fmt.Sprintf("expected %s, got %s", getJSONType(typeErr.Type), getJSONValueType(typeErr.Value))
+		if typeErr.Field == "" {
+			return newJSONError(fmt.Sprintf("%s: %s", MessageInvalidJSON, msg))
+		}
+		return newJSONError(MessageInvalidJSON, CombinedItem{
+			Keys:    strings.Split(typeErr.Field, "."),
+			Message: msg,
+		})
+
+	case errors.As(err, &syntaxErr):
+		if input == nil {
+			return newJSONError(fmt.Sprintf("%s at offset %d: %s", MessageInvalidJSON, syntaxErr.Offset, syntaxErr.Error()))
+		}
+		line, col := getLineColumn(input, syntaxErr.Offset)
+		return newJSONError(fmt.Sprintf("%s at line %d, column %d: %s", MessageInvalidJSON, line, col, syntaxErr.Error()))
+
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return newJSONError(MessageInvalidJSON + ": unexpected end of input")
+
+	case strings.HasPrefix(err.Error(), unknownFieldPrefix):
+		field, unquoteErr := strconv.Unquote(strings.TrimPrefix(err.Error(), unknownFieldPrefix))
+		if unquoteErr != nil {
+			return err
+		}
+		return newJSONError(MessageInvalidJSON, CombinedItem{
+			Keys:    strings.Split(field, "."),
+			Message: "unknown field",
+		})
+	}
+
+	return err
+}
+
+func newJSONError(msg string, items ...CombinedItem) Error {
+	e := CombinedError{
+		Code:    http.StatusBadRequest,
+		Message: msg,
+		Items:   items,
+	}
+	return *makeErrorFromCombinedError(e)
+}
+
+// getLineColumn line and column of the byte before offset in input, both start at 1
+//
+// offset of json.SyntaxError is after the invalid byte
+func getLineColumn(input []byte, offset int64) (int, int) {
+	offset--
+	if offset > int64(len(input)) {
+		offset = int64(len(input))
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	before := input[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
+
+func getJSONType(t reflect.Type) string {
+	if t == nil {
+		return "value"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Ptr:
+		return getJSONType(t.Elem())
+	}
+	return "value"
+}
+
+// getJSONValueType type of json value, value is like "string", "bool" or "number -1"
+func getJSONValueType(value string) string {
+	if idx := strings.Index(value, " "); idx >= 0 {
+		value = value[:idx]
+	}
+	if value == "bool" {
+		return "boolean"
+	}
+	return value
+}
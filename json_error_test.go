@@ -0,0 +1,94 @@
+package gerr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFromJSONErrorWithInput(t *testing.T) {
+	type item struct {
+		ProductID string `json:"productId"`
+		Quantity  int    `json:"quantity"`
+	}
+	type order struct {
+		Items []item `json:"items"`
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{
+			name:  "type mismatch",
+			input: `{"items":[{"productId":"p1","quantity":"1"}]}`,
+			want: Error{
+				Code:    http.StatusBadRequest,
+				Message: MessageInvalidJSON,
+				Errors: []*Error{
+					{
+						Target: "items",
+						Errors: []*Error{
+							{
+								Target: "0",
+								Errors: []*Error{
+									{Target: "quantity", Message: "expected number, got string"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "syntax error",
+			input: "{\n  \"items\": [}\n}",
+			want: Error{
+				Code:    http.StatusBadRequest,
+				Message: "invalid JSON body at line 2, column 13: invalid character '}' looking for beginning of value",
+			},
+		},
+		{
+			name:  "unknown field",
+			input: `{"item":[]}`,
+			want: Error{
+				Code:    http.StatusBadRequest,
+				Message: MessageInvalidJSON,
+				Errors: []*Error{
+					{Target: "item", Message: "unknown field"},
+				},
+			},
+		},
+		{
+			name:  "unexpected end",
+			input: `{"items":[`,
+			want: Error{
+				Code:    http.StatusBadRequest,
+				Message: "invalid JSON body: unexpected end of input",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := json.NewDecoder(strings.NewReader(tt.input))
+			dec.DisallowUnknownFields()
+
+			var dst order
+			err := dec.Decode(&dst)
+			if got := FromJSONErrorWithInput(err, []byte(tt.input)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromJSONErrorWithInput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromJSONError_otherError(t *testing.T) {
+	err := errors.New("read failed")
+	if got := FromJSONError(err); got != err {
+		t.Errorf("FromJSONError() = %v, want %v", got, err)
+	}
+}
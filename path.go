@@ -0,0 +1,250 @@
+package gerr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Path path of a field, the syntax is detected by its prefix
+//
+//   - dotted-bracket: "items[0].productId"
+//   - JSON Pointer: "/items/0/productId"
+//   - JSONPath-lite: "$.items[0].productId"
+type Path string
+
+// Keys split path into keys, nil if the path is invalid
+func (p Path) Keys() []string {
+	keys, err := ParsePath(string(p))
+	if err != nil {
+		return nil
+	}
+	return keys
+}
+
+// PathSyntax syntax of a path
+type PathSyntax int
+
+const (
+	// PathDotted dotted-bracket syntax, eg. items[0].productId
+	PathDotted PathSyntax = iota
+
+	// PathJSONPointer JSON Pointer syntax (RFC 6901), eg. /items/0/productId
+	PathJSONPointer
+
+	// PathJSONPath JSONPath-lite syntax, eg. $.items[0].productId
+	PathJSONPath
+)
+
+// ParsePath split path into keys, the syntax is detected by its prefix
+func ParsePath(path string) ([]string, error) {
+	switch {
+	case path == "":
+		return nil, nil
+	case strings.HasPrefix(path, "/"):
+		return ParsePathSyntax(path, PathJSONPointer)
+	case strings.HasPrefix(path, "$"):
+		return ParsePathSyntax(path, PathJSONPath)
+	}
+	return ParsePathSyntax(path, PathDotted)
+}
+
+// ParsePathSyntax split path of a syntax into keys
+func ParsePathSyntax(path string, syntax PathSyntax) ([]string, error) {
+	switch syntax {
+	case PathJSONPointer:
+		return parseJSONPointer(path)
+
+	case PathJSONPath:
+		if !strings.HasPrefix(path, "$") {
+			return nil, fmt.Errorf("gerr: JSONPath %q must start with $", path)
+		}
+		return parseDotted(path[1:], true)
+	}
+	return parseDotted(path, false)
+}
+
+// FormatPath make path of a syntax from keys
+func FormatPath(keys []string, syntax PathSyntax) string {
+	b := new(strings.Builder)
+
+	switch syntax {
+	case PathJSONPointer:
+		for _, k := range keys {
+			b.WriteString("/")
+			b.WriteString(jsonPointerEscaper.Replace(k))
+		}
+
+	case PathJSONPath:
+		b.WriteString("$")
+		for _, k := range keys {
+			switch {
+			case isIndexKey(k):
+				b.WriteString("[" + k + "]")
+			case isNameKey(k):
+				b.WriteString("." + k)
+			default:
+				b.WriteString("['" + singleQuoteEscaper.Replace(k) + "']")
+			}
+		}
+
+	default:
+		for idx, k := range keys {
+			switch {
+			case isIndexKey(k):
+				b.WriteString("[" + k + "]")
+			case isNameKey(k):
+				if idx > 0 {
+					b.WriteString(".")
+				}
+				b.WriteString(k)
+			default:
+				b.WriteString("[" + strconv.Quote(k) + "]")
+			}
+		}
+	}
+	return b.String()
+}
+
+// Path make path of a syntax from keys of the item
+func (i CombinedItem) Path(syntax PathSyntax) string {
+	return FormatPath(i.Keys, syntax)
+}
+
+var (
+	jsonPointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+	singleQuoteEscaper   = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	singleQuoteUnescaper = strings.NewReplacer(`\\`, `\`, `\'`, `'`)
+)
+
+var errEmptyKey = errors.New("gerr: empty key in path")
+
+func parseJSONPointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("gerr: JSON Pointer %q must start with /", path)
+	}
+
+	keys := strings.Split(path[1:], "/")
+	for idx, k := range keys {
+		if strings.Count(k, "~") != strings.Count(k, "~0")+strings.Count(k, "~1") {
+			return nil, fmt.Errorf("gerr: invalid escape in JSON Pointer %q", path)
+		}
+		keys[idx] = jsonPointerUnescaper.Replace(k)
+	}
+	return keys, nil
+}
+
+// parseDotted parse dotted-bracket path, leadingDot allows a dot before the first key
+func parseDotted(path string, leadingDot bool) ([]string, error) {
+	keys := []string{}
+	idx := 0
+	for idx < len(path) {
+		switch path[idx] {
+		case '[':
+			key, n, err := parseBracket(path[idx:])
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+			idx += n
+
+		case '.':
+			if idx == 0 && !leadingDot {
+				return nil, errEmptyKey
+			}
+			idx++
+			key, n := parseName(path[idx:])
+			if n == 0 {
+				return nil, errEmptyKey
+			}
+			keys = append(keys, key)
+			idx += n
+
+		default:
+			if idx > 0 {
+				return nil, fmt.Errorf("gerr: unexpected %q in path %q", path[idx], path)
+			}
+			key, n := parseName(path)
+			keys = append(keys, key)
+			idx += n
+		}
+	}
+	return keys, nil
+}
+
+func parseName(path string) (string, int) {
+	end := strings.IndexAny(path, ".[")
+	if end < 0 {
+		end = len(path)
+	}
+	return path[:end], end
+}
+
+// parseBracket parse [0], ["key"] or ['key'], the number of read bytes is returned
+func parseBracket(path string) (string, int, error) {
+	if len(path) < 2 {
+		return "", 0, fmt.Errorf("gerr: unclosed bracket in path %q", path)
+	}
+
+	quote := path[1]
+	if quote != '"' && quote != '\'' {
+		end := strings.IndexByte(path, ']')
+		if end < 0 {
+			return "", 0, fmt.Errorf("gerr: unclosed bracket in path %q", path)
+		}
+		key := strings.TrimSpace(path[1:end])
+		if key == "" {
+			return "", 0, errEmptyKey
+		}
+		return key, end + 1, nil
+	}
+
+	for idx := 2; idx < len(path); idx++ {
+		switch path[idx] {
+		case '\\':
+			idx++
+		case quote:
+			if idx+1 >= len(path) || path[idx+1] != ']' {
+				return "", 0, fmt.Errorf("gerr: unclosed bracket in path %q", path)
+			}
+			key, err := unquoteKey(path[1 : idx+1])
+			if err != nil {
+				return "", 0, fmt.Errorf("gerr: invalid quoted key in path %q", path)
+			}
+			return key, idx + 2, nil
+		}
+	}
+	return "", 0, fmt.Errorf("gerr: unclosed quote in path %q", path)
+}
+
+// unquoteKey unquote "key" as a Go string, 'key' with escaped \\ and \'
+func unquoteKey(quoted string) (string, error) {
+	if quoted[0] == '"' {
+		return strconv.Unquote(quoted)
+	}
+	return singleQuoteUnescaper.Replace(quoted[1 : len(quoted)-1]), nil
+}
+
+func isIndexKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for _, ch := range k {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isNameKey(k string) bool {
+	if k == "" || isIndexKey(k) {
+		return false
+	}
+	return !strings.ContainsAny(k, `.[]"' $/`)
+}
@@ -0,0 +1,188 @@
+package gerr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// PathMapper map Go field names in paths to wire names of a struct type
+//
+//	m := gerr.NewPathMapper(Order{}, "json")
+//	m.Map("Items[0].ProductID") // items[0].productId
+type PathMapper struct {
+	typ reflect.Type
+	tag string
+}
+
+// NewPathMapper make a path mapper for type of v by tag, eg. "json", "form" or "query"
+func NewPathMapper(v interface{}, tag string) PathMapper {
+	return PathMapper{
+		typ: reflect.TypeOf(v),
+		tag: tag,
+	}
+}
+
+// Map map a path in any syntax of ParsePath, the result is in dotted-bracket syntax
+func (m PathMapper) Map(path string) string {
+	keys, err := ParsePath(path)
+	if err != nil {
+		return path
+	}
+	return FormatPath(m.MapKeys(keys), PathDotted)
+}
+
+// MapKeys map keys, eg. ["Items", "0", "ProductID"] -> ["items", "0", "productId"]
+//
+// Embedded structs are promoted, keys which are not found
+// or ignored by the tag ("-") are kept as they are with the rest
+func (m PathMapper) MapKeys(keys []string) []string {
+	rs := make([]string, 0, len(keys))
+	t := m.typ
+
+	for idx, key := range keys {
+		t = indirectType(t)
+		if t == nil {
+			return append(rs, keys[idx:]...)
+		}
+
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			rs = append(rs, key)
+			t = t.Elem()
+			continue
+
+		case reflect.Struct:
+			names, ft, ok := m.mapField(t, key)
+			if !ok {
+				return append(rs, keys[idx:]...)
+			}
+			rs = append(rs, names...)
+			t = ft
+
+		default:
+			return append(rs, keys[idx:]...)
+		}
+	}
+	return rs
+}
+
+// MapItems map keys of combined items
+func (m PathMapper) MapItems(items []CombinedItem) []CombinedItem {
+	rs := make([]CombinedItem, len(items))
+	for idx := range items {
+		rs[idx] = CombinedItem{Keys: m.MapKeys(items[idx].Keys), Message: items[idx].Message}
+	}
+	return rs
+}
+
+// MapCombinedError map keys of items and rules of a combined key error
+func (m PathMapper) MapCombinedError(err CombinedError) CombinedError {
+	if err.Items != nil {
+		err.Items = m.MapItems(err.Items)
+	}
+
+	if err.Rules != nil {
+		rules := make([]CombinedRule, len(err.Rules))
+		for idx, rule := range err.Rules {
+			keys := make([][]string, len(rule.Keys))
+			for i := range rule.Keys {
+				keys[i] = m.MapKeys(rule.Keys[i])
+			}
+			rules[idx] = CombinedRule{Rule: rule.Rule, Keys: keys, Message: rule.Message}
+		}
+		err.Rules = rules
+	}
+	return err
+}
+
+// MapError map targets of the errors tree, a copy of the tree is returned
+func (m PathMapper) MapError(err Error) Error {
+	err.Errors = m.mapErrors(err.Errors, nil, newErrorPath(err))
+	return err
+}
+
+// mapErrors map targets of errors, keys are Go names of the parent targets
+func (m PathMapper) mapErrors(errs []*Error, keys []string, path errorPath) []*Error {
+	if len(errs) == 0 {
+		return errs
+	}
+
+	parentLen := len(m.MapKeys(keys))
+	rs := make([]*Error, 0, len(errs))
+	for idx := range errs {
+		itm := errs[idx]
+		if itm == nil || path.has(itm) {
+			continue
+		}
+
+		copy := *itm
+		currKeys := keys
+		if itm.Target != "" {
+			currKeys = append(keys[:len(keys):len(keys)], itm.Target)
+			copy.Target = strings.Join(m.MapKeys(currKeys)[parentLen:], ".")
+		}
+
+		path.errs[itm] = true
+		copy.Errors = m.mapErrors(itm.Errors, currKeys, path)
+		delete(path.errs, itm)
+
+		rs = append(rs, &copy)
+	}
+	return rs
+}
+
+// mapField map a Go field name to wire names, tagged embedded structs add their names
+func (m PathMapper) mapField(t reflect.Type, name string) ([]string, reflect.Type, bool) {
+	sf, ok := t.FieldByName(name)
+	if !ok {
+		return nil, nil, false
+	}
+
+	rs := []string{}
+	curr := t
+	for idx, i := range sf.Index {
+		curr = indirectType(curr)
+		f := curr.Field(i)
+
+		wire, ok := m.wireName(f)
+		if !ok {
+			return nil, nil, false
+		}
+		if idx == len(sf.Index)-1 || !f.Anonymous {
+			rs = append(rs, wire)
+		} else if m.tagName(f) != "" {
+			// embedded struct with a name in its tag is a named field, as in encoding/json
+			rs = append(rs, wire)
+		}
+		curr = f.Type
+	}
+	return rs, sf.Type, true
+}
+
+// wireName name of field by tag, false if the field is ignored
+func (m PathMapper) wireName(f reflect.StructField) (string, bool) {
+	tag := m.tagName(f)
+	switch tag {
+	case "-":
+		return "", false
+	case "":
+		return f.Name, true
+	}
+	return tag, true
+}
+
+// tagName name part of the tag of field, eg. "createdAt" of `json:"createdAt,omitempty"`
+func (m PathMapper) tagName(f reflect.StructField) string {
+	tag := f.Tag.Get(m.tag)
+	if idx := strings.Index(tag, ","); idx >= 0 {
+		tag = tag[:idx]
+	}
+	return tag
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
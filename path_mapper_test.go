@@ -0,0 +1,87 @@
+package gerr
+
+import (
+	"reflect"
+	"testing"
+)
+
+type mapperItem struct {
+	ProductID string `json:"productId" query:"product_id"`
+}
+
+type MapperBase struct {
+	ID string `json:"id"`
+}
+
+type MapperAudit struct {
+	By string `json:"by"`
+}
+
+type MapperTime struct {
+	CreatedAt string `json:"createdAt"`
+}
+
+type mapperOrder struct {
+	MapperBase
+	MapperAudit `json:"audit"`
+	MapperTime  `json:",omitempty"`
+	Items       []*mapperItem          `json:"items"`
+	Tags        map[string]*mapperItem `json:"tags,omitempty"`
+	Secret      string                 `json:"-"`
+	Note        string
+}
+
+func TestPathMapper_MapKeys(t *testing.T) {
+	m := NewPathMapper(&mapperOrder{}, "json")
+
+	tests := []struct {
+		keys []string
+		want []string
+	}{
+		{keys: []string{"Items", "0", "ProductID"}, want: []string{"items", "0", "productId"}},
+		{keys: []string{"Tags", "en", "ProductID"}, want: []string{"tags", "en", "productId"}},
+		{keys: []string{"ID"}, want: []string{"id"}},
+		{keys: []string{"By"}, want: []string{"audit", "by"}},
+		{keys: []string{"CreatedAt"}, want: []string{"createdAt"}},
+		{keys: []string{"Note"}, want: []string{"Note"}},
+		{keys: []string{"Secret"}, want: []string{"Secret"}},
+		{keys: []string{"Unknown", "Field"}, want: []string{"Unknown", "Field"}},
+	}
+	for _, tt := range tests {
+		t.Run(FormatPath(tt.keys, PathDotted), func(t *testing.T) {
+			if got := m.MapKeys(tt.keys); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MapKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathMapper_usages(t *testing.T) {
+	m := NewPathMapper(mapperOrder{}, "json")
+
+	if got := NewPathMapper(mapperItem{}, "query").Map("ProductID"); got != "product_id" {
+		t.Errorf("Map() = %v, want product_id", got)
+	}
+
+	got := CombinedE(400, "bad request", m, CombinedE(Path("Items[0].ProductID"), "product is required"))
+	want := []CombinedItem{{Keys: []string{"items", "0", "productId"}, Message: "product is required"}}
+	if !reflect.DeepEqual(got.Items, want) {
+		t.Errorf("CombinedE() items = %v, want %v", got.Items, want)
+	}
+
+	err := NewCombined(400, "bad request").Mapper(m).Add("Items[1].ProductID", "product is required").Err()
+	want = []CombinedItem{{Keys: []string{"items", "1", "productId"}, Message: "product is required"}}
+	if got := err.(CombinedError).Items; !reflect.DeepEqual(got, want) {
+		t.Errorf("CombinedBuilder.Err() items = %v, want %v", got, want)
+	}
+
+	mapped := m.MapError(Error{Errors: []*Error{
+		{Target: "Items", Errors: []*Error{
+			{Target: "0", Errors: []*Error{{Target: "ProductID", Message: "product is required"}}},
+		}},
+	}})
+	want = []CombinedItem{{Keys: []string{"items", "0", "productId"}, Message: "product is required"}}
+	if got := mapped.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("MapError() items = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package gerr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{path: "", want: nil},
+		{path: "name", want: []string{"name"}},
+		{path: "items[0].productId", want: []string{"items", "0", "productId"}},
+		{path: `tags["a.b"][1]`, want: []string{"tags", "a.b", "1"}},
+		{path: "/items/0/productId", want: []string{"items", "0", "productId"}},
+		{path: "/a~1b/c~0d", want: []string{"a/b", "c~d"}},
+		{path: "$.items[0]['product.id']", want: []string{"items", "0", "product.id"}},
+		{path: "$", want: []string{}},
+		{path: "items..id", wantErr: true},
+		{path: "items[0", wantErr: true},
+		{path: "/a~2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := ParsePath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePath() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatPath(t *testing.T) {
+	keys := []string{"items", "0", "product.id", "a/b"}
+	tests := []struct {
+		name   string
+		syntax PathSyntax
+		want   string
+	}{
+		{name: "dotted", syntax: PathDotted, want: `items[0]["product.id"]["a/b"]`},
+		{name: "json pointer", syntax: PathJSONPointer, want: "/items/0/product.id/a~1b"},
+		{name: "json path", syntax: PathJSONPath, want: "$.items[0]['product.id']['a/b']"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatPath(keys, tt.syntax)
+			if got != tt.want {
+				t.Errorf("FormatPath() = %v, want %v", got, tt.want)
+			}
+			if back, _ := ParsePath(got); !reflect.DeepEqual(back, keys) {
+				t.Errorf("ParsePath(FormatPath()) = %v, want %v", back, keys)
+			}
+		})
+	}
+}
+
+func TestCombinedE_path(t *testing.T) {
+	got := CombinedE(
+		"bad request",
+		400,
+		CombinedE(Target("user.name"), "name is required field"),
+		CombinedE(Path("items[0].productId"), "product is required field"),
+	)
+
+	want := CombinedError{
+		Code:    400,
+		Message: "bad request",
+		Items: []CombinedItem{
+			{Keys: []string{"user", "name"}, Message: "name is required field"},
+			{Keys: []string{"items", "0", "productId"}, Message: "product is required field"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CombinedE() = %v, want %v", got, want)
+	}
+}
+
+func TestCombinedE_topLevelPath(t *testing.T) {
+	got := CombinedE(400, Path("items[0].productId"), "product is required")
+
+	want := ErrResponse{
+		Message: "product is required",
+		Errors: ErrDetailResponse{
+			"items": map[string]interface{}{
+				"0": map[string]interface{}{
+					"productId": []interface{}{"product is required"},
+				},
+			},
+		},
+	}
+	if resp := got.ToResponseError(); !reflect.DeepEqual(resp, want) {
+		t.Errorf("ToResponseError() = %v, want %v", resp, want)
+	}
+}
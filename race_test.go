@@ -0,0 +1,7 @@
+//go:build race
+// +build race
+
+package gerr
+
+// raceEnabled the race detector is on, sync.Pool drops items randomly so allocations are not stable
+const raceEnabled = true
@@ -0,0 +1,83 @@
+package gerr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MetaKeyPanic meta key for the recovered panic value
+const MetaKeyPanic = "panic"
+
+// Recover recover a panic into err, it must be deferred directly
+//
+// The panic value is in Meta[MetaKeyPanic], an Error value is added to Errors.
+// The stack of the panic is always captured. If err is nil, the Error is panicked again
+// so the panic is not lost, eg.
+//
+//	func run() (err error) {
+//		defer gerr.Recover(&err)
+//		...
+//	}
+func Recover(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	e := newPanicError(r, 1)
+	if err == nil {
+		panic(e)
+	}
+	*err = e
+}
+
+// Go run fn in a goroutine, its panic or returned error is logged by handler
+//
+// NewSimpleLog is used if handler is nil
+func Go(fn func() error, handler Log) {
+	if handler == nil {
+		handler = NewSimpleLog()
+	}
+
+	go func() {
+		var err error
+		defer func() {
+			if err != nil {
+				handler.Log(err)
+			}
+		}()
+		defer Recover(&err)
+
+		err = fn()
+	}()
+}
+
+// newPanicError make error from a panic value, skip 0 is the caller of newPanicError
+func newPanicError(r interface{}, skip int) Error {
+	e := Error{
+		Code:    ErrPanicRecovered,
+		Message: getDefaultMessage(ErrPanicRecovered),
+	}
+
+	switch r := r.(type) {
+	case Error:
+		e.Meta = map[string]interface{}{MetaKeyPanic: r.Message}
+		e.Errors = []*Error{&r}
+	case *Error:
+		e.Meta = map[string]interface{}{MetaKeyPanic: r.Message}
+		e.Errors = []*Error{r}
+	case error:
+		e.Meta = map[string]interface{}{MetaKeyPanic: r.Error()}
+	default:
+		e.Meta = map[string]interface{}{MetaKeyPanic: fmt.Sprint(r)}
+	}
+
+	// the stack from the deferred call is Recover, runtime.gopanic, then the function which panicked
+	pcs := captureStack(skip+1, stackPolicy.depth())
+	for len(pcs) > 0 && strings.HasPrefix(Frame(pcs[0]).Function(), "runtime.") {
+		pcs = pcs[1:]
+	}
+	e.Op = getCallerName(pcs)
+	e.trace = newStackTrace(pcs)
+	return e
+}
@@ -0,0 +1,137 @@
+package gerr
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func panicValue(val interface{}) (err error) {
+	defer Recover(&err)
+
+	if val == nil {
+		var m map[string]int
+		m["key"] = 1
+	}
+	panic(val)
+}
+
+func TestRecover(t *testing.T) {
+	notFound := E(ErrIDInvalid)
+
+	tests := []struct {
+		name     string
+		val      interface{}
+		wantMeta string
+		wantErrs []*Error
+	}{
+		{name: "string", val: "boom", wantMeta: "boom"},
+		{name: "error", val: errors.New("boom"), wantMeta: "boom"},
+		{name: "runtime error", val: nil, wantMeta: "assignment to entry in nil map"},
+		{name: "gerr error", val: notFound, wantMeta: "id invalid", wantErrs: []*Error{&notFound}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err, ok := panicValue(tt.val).(Error)
+			if !ok {
+				t.Fatalf("Recover() = %T, want Error", err)
+			}
+
+			if err.Code != ErrPanicRecovered || err.Message != "panic recovered" || err.Op != "panicValue" {
+				t.Errorf("Recover() = %v", err)
+			}
+			if err.Meta[MetaKeyPanic] != tt.wantMeta {
+				t.Errorf("Meta[%v] = %v, want %v", MetaKeyPanic, err.Meta[MetaKeyPanic], tt.wantMeta)
+			}
+			if !reflect.DeepEqual(err.Errors, tt.wantErrs) {
+				t.Errorf("Errors = %v, want %v", err.Errors, tt.wantErrs)
+			}
+
+			frames := err.StackTrace()
+			if len(frames) == 0 || frames[0].Function() != "github.com/dwarvesf/gerr.panicValue" {
+				t.Errorf("StackTrace() = %v", frames)
+			}
+		})
+	}
+}
+
+func TestRecover_noPanic(t *testing.T) {
+	err := func() (err error) {
+		defer Recover(&err)
+		return nil
+	}()
+	if err != nil {
+		t.Errorf("Recover() = %v, want nil", err)
+	}
+}
+
+type logRecorder struct {
+	vals chan []interface{}
+}
+
+func (l logRecorder) Log(vals ...interface{}) error {
+	l.vals <- vals
+	return nil
+}
+func (l logRecorder) Debug(vals ...interface{}) error              { return nil }
+func (l logRecorder) Info(vals ...interface{}) error               { return nil }
+func (l logRecorder) Warn(vals ...interface{}) error               { return nil }
+func (l logRecorder) Error(vals ...interface{}) error              { return nil }
+func (l logRecorder) Errorf(str string, vals ...interface{}) error { return nil }
+
+func TestGo(t *testing.T) {
+	l := logRecorder{vals: make(chan []interface{}, 1)}
+
+	Go(func() error {
+		panic("boom")
+	}, l)
+
+	select {
+	case vals := <-l.vals:
+		err, ok := vals[0].(Error)
+		if !ok || err.Code != ErrPanicRecovered || !strings.Contains(err.Error(), "panic=boom") {
+			t.Errorf("Log() = %v", vals)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("panic is not logged")
+	}
+
+	Go(func() error {
+		return E(ErrSvcTimeout)
+	}, l)
+
+	select {
+	case vals := <-l.vals:
+		if err, ok := vals[0].(Error); !ok || err.Code != ErrSvcTimeout {
+			t.Errorf("Log() = %v", vals)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("error is not logged")
+	}
+}
+
+func TestRecover_nilErr(t *testing.T) {
+	defer func() {
+		err, ok := recover().(Error)
+		if !ok || err.Code != ErrPanicRecovered || err.Meta[MetaKeyPanic] != "boom" {
+			t.Errorf("recover() = %v, want ErrPanicRecovered", err)
+		}
+	}()
+
+	func() {
+		defer Recover(nil)
+		panic("boom")
+	}()
+	t.Errorf("panic is swallowed")
+}
+
+func TestErrPanicRecovered_code(t *testing.T) {
+	if ErrPanicRecovered != 9999 || InternalCodeCustomStart != 1007 {
+		t.Errorf("ErrPanicRecovered = %v, InternalCodeCustomStart = %v, want 9999, 1007", ErrPanicRecovered, InternalCodeCustomStart)
+	}
+	if got := getStatusCode(ErrPanicRecovered); got != 500 {
+		t.Errorf("getStatusCode() = %v, want 500", got)
+	}
+}
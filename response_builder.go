@@ -0,0 +1,233 @@
+package gerr
+
+import (
+	"reflect"
+	"time"
+)
+
+// Envelope fields of an error response
+const (
+	FieldMessage     = "message"
+	FieldErrors      = "errors"
+	FieldCode        = "code"
+	FieldTraceID     = "traceId"
+	FieldTimestamp   = "timestamp"
+	FieldOp          = "op"
+	FieldReferenceID = "referenceId"
+
+	// FieldStack stack trace of the error, only in debug mode
+	FieldStack = "stack"
+)
+
+// TemplateTag struct tag to map template fields to envelope fields
+//
+// eg. `gerr:"traceId"`
+const TemplateTag = "gerr"
+
+// ResponseEnvelope error response with configurable fields
+type ResponseEnvelope map[string]interface{}
+
+// ResponseBuilder builder for error response envelope
+type ResponseBuilder struct {
+	fields   []string
+	list     bool
+	names    map[string]string
+	template reflect.Type
+	now      func() time.Time
+}
+
+// ResponseOption option for ResponseBuilder
+type ResponseOption func(*ResponseBuilder)
+
+// NewResponseBuilder make a response builder
+//
+// The default envelope has the same fields as ErrResponse
+func NewResponseBuilder(opts ...ResponseOption) ResponseBuilder {
+	b := ResponseBuilder{
+		fields: []string{FieldMessage, FieldErrors, FieldReferenceID},
+		names:  map[string]string{},
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&b)
+	}
+	return b
+}
+
+// IncludeFields include fields in the envelope
+func IncludeFields(fields ...string) ResponseOption {
+	return func(b *ResponseBuilder) {
+		for _, f := range fields {
+			if !b.has(f) {
+				b.fields = append(b.fields, f)
+			}
+		}
+	}
+}
+
+// OmitFields omit fields from the envelope
+func OmitFields(fields ...string) ResponseOption {
+	return func(b *ResponseBuilder) {
+		rs := make([]string, 0, len(b.fields))
+		for _, f := range b.fields {
+			if !contains(fields, f) {
+				rs = append(rs, f)
+			}
+		}
+		b.fields = rs
+	}
+}
+
+// RenameField rename a field in the envelope, eg. traceId -> trace_id
+func RenameField(field, name string) ResponseOption {
+	return func(b *ResponseBuilder) {
+		b.names[field] = name
+	}
+}
+
+// WithTemplate make the envelope from a template struct
+//
+// Fields of the template are filled by their `gerr` tag,
+// the template fields and json tags decide the output
+func WithTemplate(tmpl interface{}) ResponseOption {
+	return func(b *ResponseBuilder) {
+		t := reflect.TypeOf(tmpl)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return
+		}
+		b.template = t
+	}
+}
+
+// WithListDetails make the errors field an ordered list, see ErrListResponse
+func WithListDetails() ResponseOption {
+	return func(b *ResponseBuilder) {
+		b.list = true
+	}
+}
+
+// WithClock set time source for the timestamp field
+func WithClock(now func() time.Time) ResponseOption {
+	return func(b *ResponseBuilder) {
+		b.now = now
+	}
+}
+
+// Build make error response from Error
+//
+// The result is a ResponseEnvelope, or a value of the template type if any
+func (b ResponseBuilder) Build(err Error) interface{} {
+	vals := b.makeValues(err)
+
+	if b.template != nil {
+		return b.fillTemplate(vals)
+	}
+
+	rs := ResponseEnvelope{}
+	for _, f := range b.fields {
+		val, ok := vals[f]
+		if !ok || isEmptyValue(val) {
+			continue
+		}
+		rs[b.name(f)] = val
+	}
+	return rs
+}
+
+func (b ResponseBuilder) makeValues(err Error) map[string]interface{} {
+	resp := NewResponseError(err)
+	now := b.now().UTC()
+
+	vals := map[string]interface{}{
+		FieldMessage:     resp.Message,
+		FieldErrors:      resp.Errors,
+		FieldCode:        err.Code,
+		FieldTraceID:     err.TraceID,
+		FieldTimestamp:   now.Format(time.RFC3339),
+		FieldReferenceID: resp.ReferenceID,
+	}
+
+	if b.list {
+		vals[FieldErrors] = NewListResponseError(err).Errors
+	}
+
+	if !responsePolicy.hides(err) {
+		vals[FieldOp] = err.Op
+	}
+
+	if responsePolicy.Debug {
+		vals[FieldStack] = err.StackTrace()
+	}
+	return vals
+}
+
+func (b ResponseBuilder) fillTemplate(vals map[string]interface{}) interface{} {
+	rs := reflect.New(b.template).Elem()
+
+	for idx := 0; idx < b.template.NumField(); idx++ {
+		field := b.template.Field(idx)
+		key := field.Tag.Get(TemplateTag)
+		val, ok := vals[key]
+		if key == "" || !ok || field.PkgPath != "" {
+			continue
+		}
+
+		v := reflect.ValueOf(val)
+		if key == FieldTimestamp && field.Type == reflect.TypeOf(time.Time{}) {
+			v = reflect.ValueOf(b.now().UTC())
+		}
+
+		switch {
+		case v.Type().AssignableTo(field.Type):
+			rs.Field(idx).Set(v)
+		case v.Type().ConvertibleTo(field.Type) && isSafeConversion(v.Kind(), field.Type.Kind()):
+			rs.Field(idx).Set(v.Convert(field.Type))
+		}
+	}
+	return rs.Interface()
+}
+
+func (b ResponseBuilder) has(field string) bool {
+	return contains(b.fields, field)
+}
+
+func (b ResponseBuilder) name(field string) string {
+	if name, ok := b.names[field]; ok {
+		return name
+	}
+	return field
+}
+
+// int to string conversion makes a rune, not a number text
+func isSafeConversion(from, to reflect.Kind) bool {
+	return to != reflect.String || from == reflect.String
+}
+
+func isEmptyValue(val interface{}) bool {
+	switch val := val.(type) {
+	case string:
+		return val == ""
+	case int:
+		return val == 0
+	case ErrDetailResponse:
+		return len(val) == 0
+	case []ErrItemResponse:
+		return len(val) == 0
+	case StackTrace:
+		return len(val) == 0
+	}
+	return val == nil
+}
+
+func contains(arr []string, str string) bool {
+	for idx := range arr {
+		if arr[idx] == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package gerr
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResponseBuilder_Build(t *testing.T) {
+	now := time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	err := Error{
+		TraceID: "abc123",
+		Code:    ErrRecordNotFound,
+		Message: "record not found",
+		Op:      "order.Get",
+		Errors: []*Error{
+			{Target: "id", Message: "not found"},
+		},
+	}
+
+	type envelope struct {
+		Status  int               `gerr:"code" json:"status"`
+		Message string            `gerr:"message" json:"message"`
+		Trace   string            `gerr:"traceId" json:"trace"`
+		Time    time.Time         `gerr:"timestamp" json:"time"`
+		Details ErrDetailResponse `gerr:"errors" json:"details"`
+		Ignored string            `json:"ignored"`
+	}
+
+	tests := []struct {
+		name string
+		opts []ResponseOption
+		want interface{}
+	}{
+		{
+			name: "default envelope",
+			want: ResponseEnvelope{
+				FieldMessage: "record not found",
+				FieldErrors:  ErrDetailResponse{"id": []interface{}{"not found"}},
+			},
+		},
+		{
+			name: "include, rename and omit fields",
+			opts: []ResponseOption{
+				IncludeFields(FieldCode, FieldTraceID, FieldTimestamp, FieldOp),
+				RenameField(FieldTraceID, "trace_id"),
+				OmitFields(FieldErrors),
+				WithClock(clock),
+			},
+			want: ResponseEnvelope{
+				FieldMessage:   "record not found",
+				FieldCode:      ErrRecordNotFound,
+				"trace_id":     "abc123",
+				FieldTimestamp: "2020-09-01T10:00:00Z",
+				FieldOp:        "order.Get",
+			},
+		},
+		{
+			name: "template envelope",
+			opts: []ResponseOption{
+				WithTemplate(&envelope{}),
+				WithClock(clock),
+			},
+			want: envelope{
+				Status:  ErrRecordNotFound,
+				Message: "record not found",
+				Trace:   "abc123",
+				Time:    now,
+				Details: ErrDetailResponse{"id": []interface{}{"not found"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewResponseBuilder(tt.opts...).Build(err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Build() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
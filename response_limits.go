@@ -0,0 +1,150 @@
+package gerr
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// TruncatedTarget target of the marker for truncated errors
+const TruncatedTarget = "_truncated"
+
+// ResponseLimits limits of the errors tree in responses, 0 means no limit
+//
+// MaxChildren: max children of an error
+// MaxLeaves: max errors with message in total
+// MaxDepth: max depth of the tree, children of the root are at depth 1
+type ResponseLimits struct {
+	MaxChildren int
+	MaxLeaves   int
+	MaxDepth    int
+}
+
+// responseLimits no limit by default, cycles are always removed
+var responseLimits ResponseLimits
+
+// SetResponseLimits set limits of the errors tree in responses, eg.
+//
+//	gerr.SetResponseLimits(gerr.ResponseLimits{MaxChildren: 100, MaxLeaves: 1000, MaxDepth: 32})
+//
+// NOTE: should be called before serving requests
+func SetResponseLimits(l ResponseLimits) {
+	responseLimits = l
+}
+
+// GetResponseLimits get current limits of the errors tree in responses
+func GetResponseLimits() ResponseLimits {
+	return responseLimits
+}
+
+// apply make a copy of err with the errors tree in limits
+//
+// Cycles are removed and omitted errors are counted in a marker child
+// with TruncatedTarget, eg. "and 9,950 more errors"
+func (l ResponseLimits) apply(err Error) Error {
+	t := truncator{limits: l, path: newErrorPath(err)}
+	err.Errors = t.copyErrors(err.Errors, 1)
+
+	if t.omitted > 0 {
+		err.Errors = append(err.Errors, newTruncatedError(t.omitted))
+	}
+	return err
+}
+
+type truncator struct {
+	limits ResponseLimits
+	path   errorPath
+	leaves int
+
+	// omitted errors after reaching MaxLeaves, they are marked at the root
+	omitted int
+}
+
+func (t *truncator) copyErrors(errs []*Error, depth int) []*Error {
+	if len(errs) == 0 {
+		return errs
+	}
+
+	rs := make([]*Error, 0, len(errs))
+	omitted := 0
+	for idx := range errs {
+		itm := errs[idx]
+		if itm == nil || t.path.has(itm) {
+			continue
+		}
+
+		switch {
+		case t.limits.MaxLeaves > 0 && t.leaves >= t.limits.MaxLeaves:
+			t.omitted += t.countLeaves(itm)
+			continue
+
+		case t.limits.MaxChildren > 0 && len(rs) >= t.limits.MaxChildren,
+			t.limits.MaxDepth > 0 && depth > t.limits.MaxDepth:
+			omitted += t.countLeaves(itm)
+			continue
+		}
+
+		if itm.Message != "" {
+			t.leaves++
+		}
+
+		copy := *itm
+		t.path.errs[itm] = true
+		copy.Errors = t.copyErrors(itm.Errors, depth+1)
+		delete(t.path.errs, itm)
+
+		rs = append(rs, &copy)
+	}
+
+	if omitted > 0 {
+		rs = append(rs, newTruncatedError(omitted))
+	}
+
+	if len(rs) == 0 {
+		return nil
+	}
+	return rs
+}
+
+// countLeaves number of errors with message in the tree of err
+func (t *truncator) countLeaves(err *Error) int {
+	if err == nil || t.path.has(err) {
+		return 0
+	}
+
+	rs := 0
+	if err.Message != "" {
+		rs++
+	}
+
+	t.path.errs[err] = true
+	for idx := range err.Errors {
+		rs += t.countLeaves(err.Errors[idx])
+	}
+	delete(t.path.errs, err)
+	return rs
+}
+
+func newTruncatedError(omitted int) *Error {
+	noun := "errors"
+	if omitted == 1 {
+		noun = "error"
+	}
+
+	return &Error{
+		Target:  TruncatedTarget,
+		Message: fmt.Sprintf("and %s more %s", formatCount(omitted), noun),
+	}
+}
+
+// formatCount format number with thousands separators, eg. 9,950
+func formatCount(n int) string {
+	str := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+
+	for idx := len(str) - 3; idx > 0; idx -= 3 {
+		str = str[:idx] + "," + str[idx:]
+	}
+	return str
+}
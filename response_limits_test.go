@@ -0,0 +1,133 @@
+package gerr
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestResponseLimits_apply(t *testing.T) {
+	items := &Error{Target: "items"}
+	for idx := 0; idx < 5; idx++ {
+		items.Errors = append(items.Errors, &Error{Target: strconv.Itoa(idx), Message: "invalid"})
+	}
+
+	deep := &Error{Target: "a", Errors: []*Error{
+		{Target: "b", Errors: []*Error{
+			{Target: "c", Message: "too deep"},
+		}},
+	}}
+
+	cyclic := &Error{Target: "self", Message: "cyclic"}
+	cyclic.Errors = []*Error{cyclic}
+
+	tests := []struct {
+		name   string
+		limits ResponseLimits
+		err    Error
+		want   Error
+	}{
+		{
+			name:   "max children",
+			limits: ResponseLimits{MaxChildren: 2},
+			err:    Error{Errors: []*Error{items}},
+			want: Error{Errors: []*Error{
+				{Target: "items", Errors: []*Error{
+					{Target: "0", Message: "invalid"},
+					{Target: "1", Message: "invalid"},
+					{Target: TruncatedTarget, Message: "and 3 more errors"},
+				}},
+			}},
+		},
+		{
+			name:   "max leaves",
+			limits: ResponseLimits{MaxLeaves: 4},
+			err:    Error{Errors: []*Error{items, {Target: "name", Message: "required"}}},
+			want: Error{Errors: []*Error{
+				{Target: "items", Errors: []*Error{
+					{Target: "0", Message: "invalid"},
+					{Target: "1", Message: "invalid"},
+					{Target: "2", Message: "invalid"},
+					{Target: "3", Message: "invalid"},
+				}},
+				{Target: TruncatedTarget, Message: "and 2 more errors"},
+			}},
+		},
+		{
+			name:   "max depth",
+			limits: ResponseLimits{MaxDepth: 2},
+			err:    Error{Errors: []*Error{deep}},
+			want: Error{Errors: []*Error{
+				{Target: "a", Errors: []*Error{
+					{Target: "b", Errors: []*Error{
+						{Target: TruncatedTarget, Message: "and 1 more error"},
+					}},
+				}},
+			}},
+		},
+		{
+			name:   "cycle",
+			limits: ResponseLimits{},
+			err:    Error{Errors: []*Error{cyclic}},
+			want: Error{Errors: []*Error{
+				{Target: "self", Message: "cyclic"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limits.apply(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError_Error_cycle(t *testing.T) {
+	cyclic := &Error{Target: "self", Message: "cyclic"}
+	cyclic.Errors = []*Error{cyclic}
+
+	got := Error{Message: "root", Errors: []*Error{cyclic}}.Error()
+	if !strings.HasSuffix(got, cycleMessage) {
+		t.Errorf("Error() = %v, want suffix %v", got, cycleMessage)
+	}
+}
+
+func TestError_rootCycle(t *testing.T) {
+	c := Error{Code: 400, Message: "bad request"}
+	c.Errors = []*Error{{Target: "x", Message: "m"}, &c}
+
+	want := ErrResponse{Message: "bad request", Errors: map[string]interface{}{"x": []interface{}{"m"}}}
+	if got := c.ToResponseError(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToResponseError() = %v, want %v", got, want)
+	}
+
+	items := []CombinedItem{{Keys: []string{"x"}, Message: "m"}}
+	if got := c.Flatten(); !reflect.DeepEqual(got, items) {
+		t.Errorf("Flatten() = %v, want %v", got, items)
+	}
+	if got := NewPathMapper(struct{}{}, "json").MapError(c).Flatten(); !reflect.DeepEqual(got, items) {
+		t.Errorf("MapError() items = %v, want %v", got, items)
+	}
+
+	got := c.Error()
+	if strings.Count(got, "message: m") != 1 || !strings.HasSuffix(got, cycleMessage) {
+		t.Errorf("Error() = %v, want x once and a cycle", got)
+	}
+}
+
+func Test_formatCount(t *testing.T) {
+	tests := map[int]string{0: "0", 999: "999", 9950: "9,950", 1234567: "1,234,567", -1000: "-1,000"}
+	for n, want := range tests {
+		if got := formatCount(n); got != want {
+			t.Errorf("formatCount(%v) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestGetResponseLimits_default(t *testing.T) {
+	if got := GetResponseLimits(); got != (ResponseLimits{}) {
+		t.Errorf("GetResponseLimits() = %v, want no limit", got)
+	}
+}
@@ -0,0 +1,153 @@
+package gerr
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// EnvDebug environment variable to turn on debug mode, eg. GERR_DEBUG=true
+const EnvDebug = "GERR_DEBUG"
+
+// EnvEnvironment environment variable of the running environment, eg. GERR_ENV=production
+const EnvEnvironment = "GERR_ENV"
+
+// EnvironmentProduction production environment
+const EnvironmentProduction = "production"
+
+// ResponsePolicy policy for making response from Error
+//
+// Debug: reveal full details of internal and service errors
+// Production: source code is never shown, even in debug mode
+// PublicMessage: message is returned instead of hidden message, default is the http status text
+// ReferenceID: make support reference id for hidden error, default is TraceID or the MetaKeyReferenceID meta,
+// the id should be logged with the error so support can look it up
+type ResponsePolicy struct {
+	Debug         bool
+	Production    bool
+	PublicMessage string
+	ReferenceID   func(err Error) string
+}
+
+var responsePolicy = defaultResponsePolicy()
+
+func defaultResponsePolicy() ResponsePolicy {
+	debug, _ := strconv.ParseBool(os.Getenv(EnvDebug))
+	return ResponsePolicy{
+		Debug:      debug,
+		Production: strings.EqualFold(os.Getenv(EnvEnvironment), EnvironmentProduction),
+	}
+}
+
+// SetResponsePolicy set policy for making response
+// NOTE: should be called before serving requests
+func SetResponsePolicy(p ResponsePolicy) {
+	responsePolicy = p
+}
+
+// GetResponsePolicy get current policy for making response
+func GetResponsePolicy() ResponsePolicy {
+	return responsePolicy
+}
+
+// showsSource source code around frames is shown in debug mode, except in production
+func (p ResponsePolicy) showsSource() bool {
+	return p.Debug && !p.Production
+}
+
+// hides internal and service errors (5xx) and errors without code when debug mode is off
+func (p ResponsePolicy) hides(err Error) bool {
+	if p.Debug {
+		return false
+	}
+	return err.Code <= 0 || getStatusCode(err.Code) >= http.StatusInternalServerError
+}
+
+// hidesChild children are hidden for 5xx, internal and service codes,
+// children without code are field messages and are kept
+func (p ResponsePolicy) hidesChild(err Error) bool {
+	return err.Code > 0 && p.hides(err)
+}
+
+// redact make a copy of err with hidden children replaced by the public message,
+// cycles are removed
+func (p ResponsePolicy) redact(err Error) Error {
+	if p.Debug {
+		return err
+	}
+	err.Errors = p.redactErrors(err.Errors, newErrorPath(err))
+	return err
+}
+
+func (p ResponsePolicy) redactErrors(errs []*Error, path errorPath) []*Error {
+	if len(errs) == 0 {
+		return errs
+	}
+
+	rs := make([]*Error, 0, len(errs))
+	for _, itm := range errs {
+		if itm == nil || path.has(itm) {
+			continue
+		}
+
+		if p.hidesChild(*itm) {
+			rs = append(rs, &Error{Code: itm.Code, Target: itm.Target, Message: p.publicMessage(*itm)})
+			continue
+		}
+
+		copy := *itm
+		path.errs[itm] = true
+		copy.Errors = p.redactErrors(itm.Errors, path)
+		delete(path.errs, itm)
+		rs = append(rs, &copy)
+	}
+	return rs
+}
+
+func (p ResponsePolicy) apply(err Error, rs ErrResponse) ErrResponse {
+	if !p.hides(err) {
+		return rs
+	}
+
+	return ErrResponse{
+		Message:     p.publicMessage(err),
+		ReferenceID: p.makeReferenceID(err),
+	}
+}
+
+func (p ResponsePolicy) applyList(err Error, rs ErrListResponse) ErrListResponse {
+	if !p.hides(err) {
+		return rs
+	}
+
+	return ErrListResponse{
+		Message:     p.publicMessage(err),
+		ReferenceID: p.makeReferenceID(err),
+	}
+}
+
+func (p ResponsePolicy) publicMessage(err Error) string {
+	if p.PublicMessage != "" {
+		return p.PublicMessage
+	}
+	if err.Code <= 0 {
+		return http.StatusText(http.StatusInternalServerError)
+	}
+	return http.StatusText(err.StatusCode())
+}
+
+func (p ResponsePolicy) makeReferenceID(err Error) string {
+	if p.ReferenceID != nil {
+		return p.ReferenceID(err)
+	}
+
+	if err.TraceID != "" {
+		return err.TraceID
+	}
+	if id, ok := err.Meta[MetaKeyReferenceID]; ok && id != nil {
+		return fmt.Sprint(id)
+	}
+	return ""
+}
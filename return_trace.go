@@ -0,0 +1,92 @@
+package gerr
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/dwarvesf/gerr/cleanpath"
+)
+
+// hop a site where the error was made or wrapped by E
+//
+// message is the message of the error after the hop, empty if the hop did not change it.
+// The first hop is the origin: op, message and the first frame of the stack (if any)
+// of the error before it was wrapped the first time
+type hop struct {
+	op      string
+	pc      uintptr
+	message string
+	origin  bool
+}
+
+// appendHop append the caller as a hop of wrapping base, skip 0 is the caller of appendHop
+//
+// op is the function name of the caller if it is empty, msg is the message after wrapping.
+// The hops are copied, errors wrapping the same error do not share them
+func appendHop(hops []hop, skip int, op string, base Error, msg string) []hop {
+	pcs := captureStack(skip+1, 1)
+	if len(pcs) == 0 {
+		return hops
+	}
+
+	if len(base.hops) == 0 {
+		hops = []hop{makeOriginHop(base)}
+	}
+
+	if op == "" {
+		op = getCallerName(pcs)
+	}
+	h := hop{op: op, pc: pcs[0]}
+	if msg != base.Message {
+		h.message = msg
+	}
+	return append(hops[:len(hops):len(hops)], h)
+}
+
+func makeOriginHop(e Error) hop {
+	h := hop{op: e.Op, message: e.Message, origin: true}
+	if e.trace != nil && len(e.trace.pcs) > 0 {
+		h.pc = e.trace.pcs[0]
+	}
+	return h
+}
+
+// ReturnTrace frames where the error was wrapped by E, from the first wrap
+//
+// The error was made at the first frame of StackTrace, or at its original Op if the stack
+// was not captured, the original Op is printed first in the return path of Error()
+func (e Error) ReturnTrace() StackTrace {
+	rs := make(StackTrace, 0, len(e.hops))
+	for idx := range e.hops {
+		if !e.hops[idx].origin {
+			rs = append(rs, Frame(e.hops[idx].pc))
+		}
+	}
+
+	if len(rs) == 0 {
+		return nil
+	}
+	return rs
+}
+
+// writeHops write hops as "file:line (op) message: msg", one per line
+//
+// The location of the origin is "unknown" if its stack was not captured
+func writeHops(b *bytes.Buffer, hops []hop) {
+	for idx := range hops {
+		h := hops[idx]
+
+		b.WriteString("\n")
+		if h.pc == 0 {
+			b.WriteString("unknown")
+		} else {
+			frame := Frame(h.pc).frame()
+			b.WriteString(cleanpath.Clean(frame.File) + ":" + strconv.Itoa(frame.Line))
+		}
+		b.WriteString(" (" + h.op + ")")
+
+		if h.message != "" {
+			b.WriteString(" message: " + h.message)
+		}
+	}
+}
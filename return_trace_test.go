@@ -0,0 +1,78 @@
+package gerr
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dwarvesf/gerr/cleanpath"
+)
+
+func findOrder() Error {
+	return E(ErrIDInvalid, Target("order"))
+}
+
+func getOrder() Error {
+	return E(findOrder(), Meta{"orderId": 1})
+}
+
+func handleOrder() Error {
+	return E(Message("cannot get order"), getOrder(), Op("handler"))
+}
+
+func TestE_returnTrace(t *testing.T) {
+	err := handleOrder()
+
+	want := Error{
+		Code:    ErrIDInvalid,
+		Target:  "order",
+		Message: "cannot get order",
+		Op:      "handler",
+		Meta:    map[string]interface{}{"orderId": 1},
+	}
+	got := err
+	got.trace, got.hops = nil, nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("E() = %v, want %v", got, want)
+	}
+
+	hops := make([]hop, len(err.hops))
+	for idx, h := range err.hops {
+		h.pc = 0
+		hops[idx] = h
+	}
+	wantHops := []hop{
+		{op: "findOrder", message: "id invalid", origin: true},
+		{op: "getOrder"},
+		{op: "handler", message: "cannot get order"},
+	}
+	if !reflect.DeepEqual(hops, wantHops) {
+		t.Errorf("hops = %v, want %v", hops, wantHops)
+	}
+
+	frames := err.ReturnTrace()
+	if len(frames) != 2 || frames[0].Function() != "github.com/dwarvesf/gerr.getOrder" || frames[1].Function() != "github.com/dwarvesf/gerr.handleOrder" {
+		t.Errorf("ReturnTrace() = %v", frames)
+	}
+
+	_, file, _, _ := runtime.Caller(0)
+	wantReturn := "return: \nunknown (findOrder) message: id invalid\n" +
+		cleanpath.Clean(file) + ":" + strconv.Itoa(frames[0].Line()) + " (getOrder)\n" +
+		cleanpath.Clean(file) + ":" + strconv.Itoa(frames[1].Line()) + " (handler) message: cannot get order"
+	if !strings.HasSuffix(err.Error(), wantReturn) {
+		t.Errorf("Error() = %v, want suffix %v", err.Error(), wantReturn)
+	}
+
+	base := findOrder()
+	first, second := E(base), E(base)
+	if len(base.hops) != 0 || len(first.hops) != 2 || len(second.hops) != 2 || first.hops[1].pc == second.hops[1].pc {
+		t.Errorf("hops are shared, base = %v, first = %v, second = %v", base.hops, first.hops, second.hops)
+	}
+
+	timeout := E(ErrSvcTimeout)
+	if wrapped := E(timeout); wrapped.hops[0].pc != timeout.trace.pcs[0] {
+		t.Errorf("origin hop = %v, want the first frame of the stack", wrapped.hops[0])
+	}
+}
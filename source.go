@@ -0,0 +1,150 @@
+package gerr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dwarvesf/gerr/cleanpath"
+)
+
+// sourceContext number of lines before and after the line of a frame
+const sourceContext = 3
+
+// SourceLine a line of source code around a frame
+type SourceLine struct {
+	Line    int    `json:"line"`
+	Text    string `json:"text"`
+	Current bool   `json:"current,omitempty"`
+}
+
+// sourceFiles lines of source files by path, nil if the file cannot be read
+var sourceFiles sync.Map
+
+// Format format the error
+//
+//	%s, %v   same as Error()
+//	%q       quoted Error()
+//	%+v      Error() with source code around frames of the application,
+//	         only in debug mode and not in production, see ResponsePolicy
+func (e Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('#') {
+			type plain Error
+			fmt.Fprintf(s, "%#v", plain(e))
+			return
+		}
+		io.WriteString(s, e.Error())
+		if s.Flag('+') && responsePolicy.showsSource() {
+			writeSource(s, e)
+		}
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+// writeSource write source code around frames of the application in the trace
+//
+//	source: github.com/dwarvesf/app/api/order.go:42 (GetOrder)
+//	    41 |	if err != nil {
+//	>   42 |		return gerr.E(gerr.ErrSvcTimeout)
+//	    43 |	}
+func writeSource(w io.Writer, e Error) {
+	if e.trace == nil {
+		return
+	}
+
+	b := getBuffer()
+	defer putBuffer(b)
+
+	for _, frame := range filterFrames(e.trace.getFrames()) {
+		if frame.collapsed > 0 || !isAppFrame(frame.Function, frame.File) {
+			continue
+		}
+		lines := getSourceLines(frame.File, frame.Line)
+		if len(lines) == 0 {
+			continue
+		}
+
+		b.WriteString("\nsource: " + cleanpath.Clean(frame.File) + ":" + strconv.Itoa(frame.Line) + " (" + shortFuncName(frame.Function) + ")")
+		for _, l := range lines {
+			writeSourceLine(b, l)
+		}
+	}
+	w.Write(b.Bytes())
+}
+
+func writeSourceLine(b *bytes.Buffer, l SourceLine) {
+	if l.Current {
+		b.WriteString("\n> ")
+	} else {
+		b.WriteString("\n  ")
+	}
+	num := strconv.Itoa(l.Line)
+	if len(num) < 4 {
+		b.WriteString(strings.Repeat(" ", 4-len(num)))
+	}
+	b.WriteString(num + " | " + l.Text)
+}
+
+// isAppFrame whether a frame is in the application, not in the standard library or the module cache
+//
+// Frames of the application are frames of FrameFilter.Module and main package if the module is set
+func isAppFrame(function, file string) bool {
+	pkg := getPackageName(function)
+	if pkg == "main" {
+		return true
+	}
+	if module := GetFrameFilter().Module; module != "" {
+		return matchPackage(pkg, module)
+	}
+
+	firstElem := pkg
+	if idx := strings.Index(pkg, "/"); idx >= 0 {
+		firstElem = pkg[:idx]
+	}
+	return strings.Contains(firstElem, ".") && !strings.Contains(file, "/pkg/mod/")
+}
+
+// getSourceLines lines around line in file, the file is read once and cached
+func getSourceLines(file string, line int) []SourceLine {
+	var lines []string
+	if cached, ok := sourceFiles.Load(file); ok {
+		lines = cached.([]string)
+	} else {
+		content, err := ioutil.ReadFile(file)
+		if err == nil {
+			lines = strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		}
+		sourceFiles.Store(file, lines)
+	}
+
+	if line <= 0 || line > len(lines) {
+		return nil
+	}
+
+	from, to := line-sourceContext, line+sourceContext
+	if from < 1 {
+		from = 1
+	}
+	if to > len(lines) {
+		to = len(lines)
+	}
+
+	rs := make([]SourceLine, 0, to-from+1)
+	for idx := from; idx <= to; idx++ {
+		rs = append(rs, SourceLine{
+			Line:    idx,
+			Text:    strings.TrimRight(lines[idx-1], "\r"),
+			Current: idx == line,
+		})
+	}
+	return rs
+}
@@ -0,0 +1,75 @@
+package gerr
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestError_Format(t *testing.T) {
+	defer SetResponsePolicy(GetResponsePolicy())
+
+	_, _, line, _ := runtime.Caller(0)
+	err := E(ErrSvcTimeout)
+	line++
+	current := ">   " + strconv.Itoa(line) + " | \terr := E(ErrSvcTimeout)"
+
+	tests := []struct {
+		name       string
+		policy     ResponsePolicy
+		wantSource bool
+	}{
+		{name: "debug", policy: ResponsePolicy{Debug: true}, wantSource: true},
+		{name: "not debug", policy: ResponsePolicy{}, wantSource: false},
+		{name: "production", policy: ResponsePolicy{Debug: true, Production: true}, wantSource: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetResponsePolicy(tt.policy)
+
+			got := fmt.Sprintf("%+v", err)
+			if !strings.HasPrefix(got, err.Error()) {
+				t.Errorf("Sprintf(%%+v) = %v, want prefix %v", got, err.Error())
+			}
+			if hasSource := strings.Contains(got, current); hasSource != tt.wantSource {
+				t.Errorf("Sprintf(%%+v) = %v, want source %v", got, tt.wantSource)
+			}
+			if strings.Contains(got, "source: testing") {
+				t.Errorf("Sprintf(%%+v) = %v, want no source of testing", got)
+			}
+
+			b, _ := json.Marshal(err.StackTrace())
+			var frames []frameResponse
+			if jsonErr := json.Unmarshal(b, &frames); jsonErr != nil || len(frames) == 0 {
+				t.Fatalf("MarshalJSON() = %s", b)
+			}
+			if hasSource := len(frames[0].Source) > 0; hasSource != tt.wantSource {
+				t.Errorf("MarshalJSON() = %s, want source %v", b, tt.wantSource)
+			}
+
+			if got := fmt.Sprintf("%v", err); got != err.Error() {
+				t.Errorf("Sprintf(%%v) = %v, want %v", got, err.Error())
+			}
+		})
+	}
+}
+
+func Test_getSourceLines(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+
+	got := getSourceLines(file, line)
+	if len(got) != 2*sourceContext+1 || !got[sourceContext].Current || got[sourceContext].Line != line {
+		t.Errorf("getSourceLines() = %v", got)
+	}
+
+	if got := getSourceLines(file, 1); len(got) != sourceContext+1 || got[0].Text != "package gerr" {
+		t.Errorf("getSourceLines() = %v", got)
+	}
+
+	if got := getSourceLines("not-exist.go", 1); got != nil {
+		t.Errorf("getSourceLines() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,144 @@
+package gerr
+
+import (
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// EnvStack environment variable for stack capture mode: "category", "always" or "never"
+const EnvStack = "GERR_STACK"
+
+// StackMode mode of stack capture
+type StackMode int
+
+const (
+	// StackByCategory capture stack for categories of StackPolicy
+	StackByCategory StackMode = iota
+
+	// StackAlways always capture stack
+	StackAlways
+
+	// StackNever never capture stack
+	StackNever
+)
+
+// Category category of an error code
+type Category int
+
+const (
+	// CategoryUnknown no code or code out of ranges
+	CategoryUnknown Category = iota
+
+	// CategoryClient http code below 500
+	CategoryClient
+
+	// CategoryServer http code from 500
+	CategoryServer
+
+	// CategoryInternal internal code
+	CategoryInternal
+
+	// CategoryService service code
+	CategoryService
+
+	// CategoryBusiness business code
+	CategoryBusiness
+)
+
+// GetCategory get category of an error code
+func GetCategory(code int) Category {
+	switch {
+	case code <= 0:
+		return CategoryUnknown
+	case code < http.StatusInternalServerError:
+		return CategoryClient
+	case code <= httpMaxLength:
+		return CategoryServer
+	case code < internalCodeMax:
+		return CategoryInternal
+	case code < serviceCodeMax:
+		return CategoryService
+	case code < businessCodeMax:
+		return CategoryBusiness
+	}
+	return CategoryUnknown
+}
+
+// StackPolicy policy of stack capture for E, ErrorBuilder.Err and CombinedError.ToError
+//
+// Mode: mode of capture
+// Categories: categories which capture stack in StackByCategory mode
+// Codes: capture or not for codes, it overrides Mode
+// SampleRate: fraction of errors which capture stack, 0 means 1
+// MaxDepth: max number of frames, 0 means 32
+type StackPolicy struct {
+	Mode       StackMode
+	Categories map[Category]bool
+	Codes      map[int]bool
+	SampleRate float64
+	MaxDepth   int
+}
+
+var stackPolicy = defaultStackPolicy()
+
+// DefaultStackCategories categories which capture stack by default,
+// expected errors (client and business) do not
+func DefaultStackCategories() map[Category]bool {
+	return map[Category]bool{
+		CategoryUnknown:  true,
+		CategoryServer:   true,
+		CategoryInternal: true,
+		CategoryService:  true,
+	}
+}
+
+func defaultStackPolicy() StackPolicy {
+	p := StackPolicy{Categories: DefaultStackCategories()}
+
+	switch strings.ToLower(os.Getenv(EnvStack)) {
+	case "always":
+		p.Mode = StackAlways
+	case "never":
+		p.Mode = StackNever
+	}
+	return p
+}
+
+// SetStackPolicy set policy of stack capture
+// NOTE: should be called before making errors
+func SetStackPolicy(p StackPolicy) {
+	stackPolicy = p
+}
+
+// GetStackPolicy get current policy of stack capture
+func GetStackPolicy() StackPolicy {
+	return stackPolicy
+}
+
+func (p StackPolicy) captures(code int) bool {
+	capture, ok := p.Codes[code]
+	if !ok {
+		switch p.Mode {
+		case StackAlways:
+			capture = true
+		case StackNever:
+			capture = false
+		default:
+			capture = p.Categories[GetCategory(code)]
+		}
+	}
+
+	if !capture {
+		return false
+	}
+	return p.SampleRate <= 0 || p.SampleRate >= 1 || rand.Float64() < p.SampleRate
+}
+
+func (p StackPolicy) depth() int {
+	if p.MaxDepth > 0 {
+		return p.MaxDepth
+	}
+	return maxStackDepth
+}
@@ -0,0 +1,97 @@
+package gerr
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetCategory(t *testing.T) {
+	tests := map[int]Category{
+		0:                              CategoryUnknown,
+		http.StatusBadRequest:          CategoryClient,
+		http.StatusInternalServerError: CategoryServer,
+		ErrIOContentReachLimit:         CategoryInternal,
+		ErrSvcLostConnection:           CategoryService,
+		ErrIDInvalid:                   CategoryBusiness,
+	}
+	for code, want := range tests {
+		if got := GetCategory(code); got != want {
+			t.Errorf("GetCategory(%v) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+func TestStackPolicy(t *testing.T) {
+	defer SetStackPolicy(GetStackPolicy())
+
+	tests := []struct {
+		name   string
+		policy StackPolicy
+		code   int
+		want   bool
+	}{
+		{
+			name:   "business error by category",
+			policy: StackPolicy{Categories: DefaultStackCategories()},
+			code:   ErrIDInvalid,
+			want:   false,
+		},
+		{
+			name:   "service error by category",
+			policy: StackPolicy{Categories: DefaultStackCategories()},
+			code:   ErrSvcLostConnection,
+			want:   true,
+		},
+		{
+			name:   "always",
+			policy: StackPolicy{Mode: StackAlways},
+			code:   ErrIDInvalid,
+			want:   true,
+		},
+		{
+			name:   "never",
+			policy: StackPolicy{Mode: StackNever},
+			code:   ErrSvcLostConnection,
+			want:   false,
+		},
+		{
+			name:   "code overrides mode",
+			policy: StackPolicy{Mode: StackNever, Codes: map[int]bool{ErrIDInvalid: true}},
+			code:   ErrIDInvalid,
+			want:   true,
+		},
+		{
+			name:   "sampled out",
+			policy: StackPolicy{Mode: StackAlways, SampleRate: 1e-12},
+			code:   ErrIDInvalid,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetStackPolicy(tt.policy)
+
+			err := E(tt.code)
+			if got := err.StackTrace() != nil; got != tt.want {
+				t.Errorf("E() has stack = %v, want %v", got, tt.want)
+			}
+			if err.Op == "" {
+				t.Errorf("E() has no op")
+			}
+
+			combined := CombinedE(tt.code, CombinedItem{Keys: []string{"id"}, Message: "invalid"})
+			if got := combined.ToError().StackTrace() != nil; got != tt.want {
+				t.Errorf("ToError() has stack = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStackPolicy_MaxDepth(t *testing.T) {
+	defer SetStackPolicy(GetStackPolicy())
+
+	SetStackPolicy(StackPolicy{Mode: StackAlways, MaxDepth: 2})
+	if frames := E(ErrIDInvalid).StackTrace(); len(frames) != 2 {
+		t.Errorf("StackTrace() has %v frames, want 2", len(frames))
+	}
+}
@@ -1,17 +1,148 @@
 package gerr
 
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dwarvesf/gerr/cleanpath"
+)
+
+// maxStackDepth default max number of frames in a stack trace
+const maxStackDepth = 32
+
+// estimatedFrameSize size of a printed frame to grow the buffer once
+const estimatedFrameSize = 128
+
+// stacktrace program counters of the current goroutine,
+// they are symbolized lazily when the trace is printed
 type stacktrace struct {
-	file      string
-	line      int
-	function  string
-	fullTrace string
+	pcs []uintptr
+
+	once   sync.Once
+	frames []runtime.Frame
+
+	// rendered cache of String, renderedTrace
+	rendered atomic.Value
+}
+
+// renderKey settings which printed traces depend on
+type renderKey struct {
+	filterGen uint64
+	repoRoot  string
+}
+
+func getRenderKey() renderKey {
+	return renderKey{
+		filterGen: loadFrameFilter().gen,
+		repoRoot:  cleanpath.GetRepoRoot(),
+	}
+}
+
+// renderedTrace string of a trace for the settings
+type renderedTrace struct {
+	key renderKey
+	str string
+}
+
+func newStackTrace(pcs []uintptr) *stacktrace {
+	return &stacktrace{pcs: pcs}
 }
 
-func newStackTrace(file string, line int, fnName string, fullTrace string) stacktrace {
-	return stacktrace{
-		file:      file,
-		line:      line,
-		function:  fnName,
-		fullTrace: fullTrace,
+// captureStack capture program counters of the caller, skip 0 is the caller of captureStack
+func captureStack(skip int, depth int) []uintptr {
+	if depth > maxStackDepth {
+		pcs := make([]uintptr, depth)
+		n := runtime.Callers(skip+2, pcs)
+		return pcs[:n:n]
 	}
+
+	var buf [maxStackDepth]uintptr
+	n := runtime.Callers(skip+2, buf[:depth])
+
+	pcs := make([]uintptr, n)
+	copy(pcs, buf[:n])
+	return pcs
+}
+
+// getFrames symbolize program counters
+func (s *stacktrace) getFrames() []runtime.Frame {
+	s.once.Do(func() {
+		frames := runtime.CallersFrames(s.pcs)
+		s.frames = make([]runtime.Frame, 0, len(s.pcs))
+		for {
+			frame, more := frames.Next()
+			s.frames = append(s.frames, frame)
+			if !more {
+				break
+			}
+		}
+	})
+	return s.frames
+}
+
+// String format the trace like runtime.Stack, the first line is the caller
+//
+// Frames are filtered by FrameFilter, the result is cached until the filter or
+// the repo root of cleanpath is changed
+func (s *stacktrace) String() string {
+	key := getRenderKey()
+	if r, ok := s.rendered.Load().(renderedTrace); ok && r.key == key {
+		return r.str
+	}
+
+	str := s.format()
+	s.rendered.Store(renderedTrace{key: key, str: str})
+	return str
+}
+
+func (s *stacktrace) format() string {
+	frames := s.getFrames()
+	if len(frames) == 0 {
+		return ""
+	}
+
+	b := new(strings.Builder)
+	b.Grow(estimatedFrameSize * (len(frames) + 1))
+
+	caller := frames[0]
+	writeFileLine(b, caller.File, caller.Line)
+	b.WriteString(" (" + shortFuncName(caller.Function) + ")")
+
+	if loadFrameFilter().filter.isZero() {
+		for idx := range frames {
+			b.WriteString("\n")
+			writeFrameLines(b, frames[idx].Function, frames[idx].File, frames[idx].Line)
+		}
+		return b.String()
+	}
+
+	for _, frame := range filterFrames(frames) {
+		b.WriteString("\n")
+		writeFrame(b, frame)
+	}
+	return b.String()
+}
+
+// getCallerName short function name of the first program counter
+func getCallerName(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+
+	// the program counter is a return address, the call is the instruction before it
+	fn := runtime.FuncForPC(pcs[0] - 1)
+	if fn == nil {
+		return ""
+	}
+	return shortFuncName(fn.Name())
+}
+
+// getCallerNameFast short function name of the caller without allocation,
+// skip 0 is the caller of getCallerNameFast
+func getCallerNameFast(skip int) string {
+	var pcs [1]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	return getCallerName(pcs[:n])
 }
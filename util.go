@@ -1,11 +1,37 @@
 package gerr
 
-import "bytes"
+import (
+	"bytes"
+	"sync"
+)
 
-// pad appends str to the buffer if the buffer already has some data.
-func pad(b *bytes.Buffer, str string) {
-	if b.Len() == 0 {
+// maxPooledBufferSize buffers larger than it are not put back to the pool
+const maxPooledBufferSize = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	b := bufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+	return b
+}
+
+func putBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(b)
+}
+
+// pad appends str to the buffer if the buffer already has some data after start.
+func pad(b *bytes.Buffer, start int, str string) {
+	if b.Len() == start {
 		return
 	}
-	b.WriteString(" " + str)
+	b.WriteByte(' ')
+	b.WriteString(str)
 }
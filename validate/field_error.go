@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/dwarvesf/gerr"
+)
+
+// FieldError error of a field from a validator
+//
+// It is compatible with FieldError of github.com/go-playground/validator
+type FieldError interface {
+	// eg. JSON name "User.items[0].productId"
+	Namespace() string
+
+	// eg. JSON name "productId"
+	Field() string
+
+	// eg. "required"
+	Tag() string
+
+	// eg. "10" for "max=10"
+	Param() string
+}
+
+// FromFieldErrors make combined key error from field errors
+//
+// Keys are split from Namespace() without the root struct name,
+// messages are made from templates of the tags, see RegisterMessage
+func FromFieldErrors(errs []FieldError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	items := make([]gerr.CombinedItem, 0, len(errs))
+	for idx := range errs {
+		fe := errs[idx]
+		items = append(items, gerr.CombinedItem{
+			Keys:    SplitNamespace(fe.Namespace()),
+			Message: Message(fe.Tag(), fe.Field(), fe.Param()),
+		})
+	}
+	return gerr.CombinedE(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), items)
+}
+
+// FromError make combined key error from an error of a validator,
+// eg. validator.ValidationErrors
+//
+// err is returned as is when it has no field errors
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if fe, ok := err.(FieldError); ok {
+		return FromFieldErrors([]FieldError{fe})
+	}
+
+	v := reflect.ValueOf(err)
+	if v.Kind() != reflect.Slice {
+		return err
+	}
+
+	errs := make([]FieldError, 0, v.Len())
+	for idx := 0; idx < v.Len(); idx++ {
+		fe, ok := v.Index(idx).Interface().(FieldError)
+		if !ok {
+			return err
+		}
+		errs = append(errs, fe)
+	}
+	return FromFieldErrors(errs)
+}
+
+// SplitNamespace split namespace into keys without the root struct name
+//
+// eg. "User.Items[0].ProductID" -> ["Items", "0", "ProductID"]
+func SplitNamespace(ns string) []string {
+	keys, err := gerr.ParsePathSyntax(ns, gerr.PathDotted)
+	if err != nil {
+		return []string{ns}
+	}
+
+	if len(keys) > 1 {
+		keys = keys[1:]
+	}
+	return keys
+}
@@ -0,0 +1,62 @@
+package validate
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/dwarvesf/gerr"
+)
+
+type fieldError struct {
+	ns, field, tag, param string
+}
+
+func (e fieldError) Namespace() string { return e.ns }
+func (e fieldError) Field() string     { return e.field }
+func (e fieldError) Tag() string       { return e.tag }
+func (e fieldError) Param() string     { return e.param }
+func (e fieldError) Error() string     { return e.ns + " " + e.tag }
+
+// fieldErrors mimics validator.ValidationErrors
+type fieldErrors []fieldError
+
+func (e fieldErrors) Error() string { return "validation failed" }
+
+func TestSplitNamespace(t *testing.T) {
+	tests := []struct {
+		ns   string
+		want []string
+	}{
+		{ns: "User.name", want: []string{"name"}},
+		{ns: "User.Items[0].ProductID", want: []string{"Items", "0", "ProductID"}},
+		{ns: "User.Tags[en][1]", want: []string{"Tags", "en", "1"}},
+		{ns: "name", want: []string{"name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ns, func(t *testing.T) {
+			if got := SplitNamespace(tt.ns); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitNamespace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromError(t *testing.T) {
+	err := fieldErrors{
+		{ns: "User.items[0].productId", field: "productId", tag: "required"},
+		{ns: "User.name", field: "name", tag: "max", param: "10"},
+	}
+
+	want := gerr.CombinedError{
+		Code:    http.StatusBadRequest,
+		Message: http.StatusText(http.StatusBadRequest),
+		Items: []gerr.CombinedItem{
+			{Keys: []string{"items", "0", "productId"}, Message: "productId is required"},
+			{Keys: []string{"name"}, Message: "name must be at most 10"},
+		},
+	}
+	if got := FromError(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("FromError() = %#v, want %#v", got, want)
+	}
+}
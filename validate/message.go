@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"strings"
+	"sync"
+)
+
+// Placeholders in message templates
+const (
+	PlaceholderField = "{field}"
+	PlaceholderParam = "{param}"
+)
+
+// defaultMessage message for tags without template
+const defaultMessage = "{field} is invalid"
+
+var (
+	messagesMu sync.RWMutex
+	messages   = map[string]string{
+		"required": "{field} is required",
+		"min":      "{field} must be at least {param}",
+		"max":      "{field} must be at most {param}",
+		"len":      "{field} must be {param} in length",
+		"email":    "{field} must be a valid email",
+		"oneof":    "{field} must be one of [{param}]",
+		"regexp":   "{field} has invalid format",
+	}
+)
+
+// RegisterMessage register message template for a tag
+//
+// eg. RegisterMessage("required", "{field} is required field")
+func RegisterMessage(tag, tmpl string) {
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+	messages[tag] = tmpl
+}
+
+// Message make message for a tag from its template
+func Message(tag, field, param string) string {
+	messagesMu.RLock()
+	tmpl, ok := messages[tag]
+	messagesMu.RUnlock()
+	if !ok {
+		tmpl = defaultMessage
+	}
+
+	r := strings.NewReplacer(PlaceholderField, field, PlaceholderParam, param)
+	return r.Replace(tmpl)
+}
@@ -0,0 +1,194 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	tagOmitEmpty = "omitempty"
+	tagRequired  = "required"
+	tagMin       = "min"
+	tagMax       = "max"
+	tagLen       = "len"
+	tagEmail     = "email"
+	tagOneOf     = "oneof"
+	tagRegexp    = "regexp"
+	tagDive      = "dive"
+)
+
+var emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+type rule struct {
+	tag   string
+	param string
+	check func(v reflect.Value) bool
+}
+
+// ruleSet rules of a field, rules after `dive` are applied to each element
+type ruleSet struct {
+	omitEmpty bool
+	rules     []rule
+	dive      *ruleSet
+}
+
+var patterns sync.Map
+
+// parseRules parse rules from a tag, eg. "required,min=1,dive,required"
+//
+// NOTE: regexp must be the last rule of its level, the rest of the tag is its pattern
+func parseRules(tag string) *ruleSet {
+	rs := &ruleSet{}
+	for tag != "" {
+		var part string
+		if strings.HasPrefix(tag, tagRegexp+"=") {
+			part, tag = tag, ""
+		} else if idx := strings.Index(tag, ","); idx >= 0 {
+			part, tag = tag[:idx], tag[idx+1:]
+		} else {
+			part, tag = tag, ""
+		}
+
+		name, param := part, ""
+		if idx := strings.Index(part, "="); idx >= 0 {
+			name, param = part[:idx], part[idx+1:]
+		}
+
+		switch name {
+		case "":
+			continue
+		case tagOmitEmpty:
+			rs.omitEmpty = true
+		case tagDive:
+			rs.dive = parseRules(tag)
+			return rs
+		default:
+			rs.rules = append(rs.rules, rule{
+				tag:   name,
+				param: param,
+				check: makeCheck(name, param),
+			})
+		}
+	}
+	return rs
+}
+
+func makeCheck(name, param string) func(v reflect.Value) bool {
+	switch name {
+	case tagRequired:
+		return checkRequired
+
+	case tagMin:
+		n := mustParseFloat(name, param)
+		return func(v reflect.Value) bool {
+			size, ok := sizeOf(v)
+			return !ok || size >= n
+		}
+
+	case tagMax:
+		n := mustParseFloat(name, param)
+		return func(v reflect.Value) bool {
+			size, ok := sizeOf(v)
+			return !ok || size <= n
+		}
+
+	case tagLen:
+		n := mustParseFloat(name, param)
+		return func(v reflect.Value) bool {
+			size, ok := sizeOf(v)
+			return !ok || size == n
+		}
+
+	case tagEmail:
+		return func(v reflect.Value) bool {
+			return v.Kind() != reflect.String || emailRegexp.MatchString(v.String())
+		}
+
+	case tagOneOf:
+		vals := strings.Fields(param)
+		return func(v reflect.Value) bool {
+			str := valueString(v)
+			for idx := range vals {
+				if vals[idx] == str {
+					return true
+				}
+			}
+			return false
+		}
+
+	case tagRegexp:
+		re := compilePattern(param)
+		return func(v reflect.Value) bool {
+			return v.Kind() != reflect.String || re.MatchString(v.String())
+		}
+
+	default:
+		panic(fmt.Sprintf("validate: unknown rule %q", name))
+	}
+}
+
+func valueString(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	}
+	return fmt.Sprint(v)
+}
+
+func checkRequired(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String, reflect.Array:
+		return v.Len() > 0
+	case reflect.Ptr, reflect.Interface:
+		return !v.IsNil()
+	}
+	return !v.IsZero()
+}
+
+// sizeOf value of numbers, length of strings and collections
+func sizeOf(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(v.Len()), true
+	}
+	return 0, false
+}
+
+func mustParseFloat(name, param string) float64 {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validate: invalid param %q for rule %q", param, name))
+	}
+	return n
+}
+
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+
+	re := regexp.MustCompile(pattern)
+	patterns.Store(pattern, re)
+	return re
+}
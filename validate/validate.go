@@ -0,0 +1,214 @@
+// Package validate validates structs by tags and returns gerr.CombinedError
+//
+// Rules are read from the `validate` tag, eg.
+//
+//	type Order struct {
+//		Email string `json:"email" validate:"required,email"`
+//		Items []Item `json:"items" validate:"required,min=1,dive"`
+//	}
+//
+// Supported rules: omitempty, required, min, max, len, email, oneof, regexp and dive.
+// Keys of errors are json field names, eg. ["items", "0", "productId"].
+package validate
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dwarvesf/gerr"
+)
+
+// TagName struct tag for validation rules
+const TagName = "validate"
+
+type field struct {
+	index    int
+	name     string
+	embedded bool
+	rules    *ruleSet
+}
+
+var fieldCache sync.Map
+
+// Struct validate a struct, or a pointer to struct, by its tags
+//
+// nil is returned when v is valid, otherwise a gerr.CombinedError
+func Struct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: expect a struct, got %T", v)
+	}
+
+	items := validateStruct(rv, nil, nil)
+	if len(items) == 0 {
+		return nil
+	}
+	return gerr.CombinedE(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), items)
+}
+
+func validateStruct(v reflect.Value, keys []string, items []gerr.CombinedItem) []gerr.CombinedItem {
+	fields := getFields(v.Type())
+	for idx := range fields {
+		f := fields[idx]
+		fv := v.Field(f.index)
+
+		if f.embedded {
+			fv = indirect(fv)
+			if fv.Kind() == reflect.Struct {
+				items = validateStruct(fv, keys, items)
+			}
+			continue
+		}
+
+		fieldKeys := append(keys[:len(keys):len(keys)], f.name)
+		items = validateValue(fv, fieldKeys, f.name, f.rules, items)
+	}
+	return items
+}
+
+func validateValue(v reflect.Value, keys []string, name string, rules *ruleSet, items []gerr.CombinedItem) []gerr.CombinedItem {
+	if rules.omitEmpty && !checkRequired(v) {
+		return items
+	}
+
+	for idx := range rules.rules {
+		r := rules.rules[idx]
+		val := v
+		if r.tag != tagRequired {
+			val = indirect(v)
+			if !val.IsValid() {
+				continue
+			}
+		}
+
+		if !r.check(val) {
+			return append(items, gerr.CombinedItem{
+				Keys:    keys,
+				Message: Message(r.tag, name, r.param),
+			})
+		}
+	}
+
+	v = indirect(v)
+	if rules.dive != nil {
+		return validateElements(v, keys, name, rules.dive, items)
+	}
+
+	if v.Kind() == reflect.Struct {
+		return validateStruct(v, keys, items)
+	}
+	return items
+}
+
+func validateElements(v reflect.Value, keys []string, name string, rules *ruleSet, items []gerr.CombinedItem) []gerr.CombinedItem {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for idx := 0; idx < v.Len(); idx++ {
+			key := strconv.Itoa(idx)
+			elemKeys := append(keys[:len(keys):len(keys)], key)
+			items = validateValue(v.Index(idx), elemKeys, name+"["+key+"]", rules, items)
+		}
+
+	case reflect.Map:
+		mapKeys := v.MapKeys()
+		keyStrs := make([]string, len(mapKeys))
+		for idx := range mapKeys {
+			keyStrs[idx] = valueString(mapKeys[idx])
+		}
+		sortKeys(mapKeys, keyStrs)
+
+		for idx := range mapKeys {
+			key := keyStrs[idx]
+			elemKeys := append(keys[:len(keys):len(keys)], key)
+			items = validateValue(v.MapIndex(mapKeys[idx]), elemKeys, name+"["+key+"]", rules, items)
+		}
+	}
+	return items
+}
+
+// sortKeys sort map keys by their string for a stable order
+func sortKeys(keys []reflect.Value, strs []string) {
+	sort.Sort(mapKeys{keys: keys, strs: strs})
+}
+
+type mapKeys struct {
+	keys []reflect.Value
+	strs []string
+}
+
+func (m mapKeys) Len() int           { return len(m.strs) }
+func (m mapKeys) Less(i, j int) bool { return m.strs[i] < m.strs[j] }
+func (m mapKeys) Swap(i, j int) {
+	m.strs[i], m.strs[j] = m.strs[j], m.strs[i]
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func getFields(t reflect.Type) []field {
+	if fields, ok := fieldCache.Load(t); ok {
+		return fields.([]field)
+	}
+
+	fields := make([]field, 0, t.NumField())
+	for idx := 0; idx < t.NumField(); idx++ {
+		sf := t.Field(idx)
+		name, ok := jsonName(sf)
+		if !ok {
+			continue
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			fields = append(fields, field{index: idx, embedded: true})
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{
+			index: idx,
+			name:  name,
+			rules: parseRules(sf.Tag.Get(TagName)),
+		})
+	}
+
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// jsonName name of field in json tag, false if the field is ignored
+func jsonName(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if idx := strings.Index(tag, ","); idx >= 0 {
+		tag = tag[:idx]
+	}
+	return tag, true
+}
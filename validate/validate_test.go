@@ -0,0 +1,94 @@
+package validate
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/dwarvesf/gerr"
+)
+
+type item struct {
+	ProductID string `json:"productId" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"min=1,max=10"`
+}
+
+type Base struct {
+	ID string `json:"id" validate:"len=4"`
+}
+
+type order struct {
+	Base
+	Email    string            `json:"email" validate:"required,email"`
+	Status   string            `json:"status" validate:"oneof=new paid"`
+	Code     string            `json:"code" validate:"omitempty,regexp=^[A-Z]{2,3}$"`
+	Note     *string           `json:"note,omitempty" validate:"omitempty,max=5"`
+	Items    []item            `json:"items" validate:"required,dive"`
+	Tags     map[string]string `json:"tags" validate:"dive,max=3"`
+	Internal string            `json:"-" validate:"required"`
+}
+
+func TestStruct(t *testing.T) {
+	note := "too long note"
+
+	tests := []struct {
+		name string
+		v    interface{}
+		want error
+	}{
+		{
+			name: "valid struct",
+			v: &order{
+				Base:   Base{ID: "o-01"},
+				Email:  "a@b.co",
+				Status: "new",
+				Items:  []item{{ProductID: "p1", Quantity: 1}},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid struct",
+			v: order{
+				Base:   Base{ID: "o-1"},
+				Email:  "ab.co",
+				Status: "closed",
+				Code:   "abc",
+				Note:   &note,
+				Items:  []item{{ProductID: "p1", Quantity: 1}, {Quantity: 11}},
+				Tags:   map[string]string{"b": "long", "a": "ok"},
+			},
+			want: gerr.CombinedError{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Items: []gerr.CombinedItem{
+					{Keys: []string{"id"}, Message: "id must be 4 in length"},
+					{Keys: []string{"email"}, Message: "email must be a valid email"},
+					{Keys: []string{"status"}, Message: "status must be one of [new paid]"},
+					{Keys: []string{"code"}, Message: "code has invalid format"},
+					{Keys: []string{"note"}, Message: "note must be at most 5"},
+					{Keys: []string{"items", "1", "productId"}, Message: "productId is required"},
+					{Keys: []string{"items", "1", "quantity"}, Message: "quantity must be at most 10"},
+					{Keys: []string{"tags", "b"}, Message: "tags[b] must be at most 3"},
+				},
+			},
+		},
+		{
+			name: "required slice",
+			v:    order{Base: Base{ID: "o-01"}, Email: "a@b.co", Status: "paid"},
+			want: gerr.CombinedError{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Items: []gerr.CombinedItem{
+					{Keys: []string{"items"}, Message: "items is required"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Struct(tt.v); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Struct() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}